@@ -14,11 +14,17 @@ language governing permissions and limitations under the License.
 package client
 
 import (
+	"time"
+
 	"google.golang.org/grpc"
 
 	fulfillmentv1 "github.com/innabox/fulfillment-common/api/fulfillment/v1"
 )
 
+// DefaultMaxConcurrentRequests is the default maximum number of requests a single data source or resource sends
+// to the API concurrently.
+const DefaultMaxConcurrentRequests = 8
+
 // ProviderData holds the gRPC clients that are passed to resources and data sources.
 type ProviderData struct {
 	Conn                           *grpc.ClientConn
@@ -29,4 +35,23 @@ type ProviderData struct {
 	HostsClient                    fulfillmentv1.HostsClient
 	HostClassesClient              fulfillmentv1.HostClassesClient
 	HostPoolsClient                fulfillmentv1.HostPoolsClient
+	Wait                           WaitSettings
+	MaxConcurrentRequests          int
+}
+
+// WaitSettings holds the provider-wide settings used when waiting for objects to become ready.
+type WaitSettings struct {
+	// SlowWarningAfter is the elapsed time after which a warning is logged if the object is still not ready.
+	// Zero disables the warning.
+	SlowWarningAfter time.Duration
+	// Disabled makes resources return without waiting for objects to become ready, unless they override it.
+	Disabled bool
+	// PollInterval, when not zero, is the fixed interval between the polls of every wait. Otherwise waits back off
+	// from MinPollInterval to 10s. Zero values use the waiter defaults.
+	PollInterval    time.Duration
+	MinPollInterval time.Duration
+	// BackoffMultiplier and MaxPollInterval enable exponential backoff between polls. Zero keeps the constant
+	// interval.
+	BackoffMultiplier float64
+	MaxPollInterval   time.Duration
 }
@@ -0,0 +1,68 @@
+/*
+Copyright (c) 2025 Red Hat Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License"); you may not use this file except in compliance with the
+License. You may obtain a copy of the License at
+
+  http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software distributed under the License is distributed on an
+"AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the License for the specific
+language governing permissions and limitations under the License.
+*/
+
+package client
+
+import (
+	"strings"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+
+	fulfillmentv1 "github.com/innabox/fulfillment-common/api/fulfillment/v1"
+)
+
+// ConditionAttrTypes are the attribute types of the elements of the conditions attribute.
+var ConditionAttrTypes = map[string]attr.Type{
+	"type":                 types.StringType,
+	"status":               types.StringType,
+	"reason":               types.StringType,
+	"message":              types.StringType,
+	"last_transition_time": types.StringType,
+}
+
+// ClusterConditionsValue converts the conditions of the status of a cluster to the value of the conditions
+// attribute. Transition times are formatted as RFC 3339 and are null when the server doesn't report them.
+func ClusterConditionsValue(conditions []*fulfillmentv1.ClusterCondition) types.List {
+	elements := make([]attr.Value, 0, len(conditions))
+	for _, condition := range conditions {
+		lastTransitionTime := types.StringNull()
+		if condition.GetLastTransitionTime() != nil {
+			lastTransitionTime = types.StringValue(condition.GetLastTransitionTime().AsTime().Format(time.RFC3339))
+		}
+		elements = append(elements, types.ObjectValueMust(ConditionAttrTypes, map[string]attr.Value{
+			"type":                 types.StringValue(condition.GetType().String()),
+			"status":               types.StringValue(condition.GetStatus().String()),
+			"reason":               types.StringValue(condition.GetReason()),
+			"message":              types.StringValue(condition.GetMessage()),
+			"last_transition_time": lastTransitionTime,
+		}))
+	}
+	return types.ListValueMust(types.ObjectType{AttrTypes: ConditionAttrTypes}, elements)
+}
+
+// FailureReason returns the messages of the given status conditions, formatted to be appended to an error message,
+// or an empty string if there are none.
+func FailureReason[T interface{ GetMessage() string }](conditions []T) string {
+	var messages []string
+	for _, condition := range conditions {
+		if condition.GetMessage() != "" {
+			messages = append(messages, condition.GetMessage())
+		}
+	}
+	if len(messages) == 0 {
+		return ""
+	}
+	return ": " + strings.Join(messages, "; ")
+}
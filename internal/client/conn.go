@@ -0,0 +1,59 @@
+/*
+Copyright (c) 2025 Red Hat Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License"); you may not use this file except in compliance with the
+License. You may obtain a copy of the License at
+
+  http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software distributed under the License is distributed on an
+"AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the License for the specific
+language governing permissions and limitations under the License.
+*/
+
+package client
+
+import (
+	"context"
+
+	"google.golang.org/grpc"
+)
+
+// Conn wraps a gRPC connection so that the calls made by the generated clients go through a chain of unary
+// interceptors. It satisfies grpc.ClientConnInterface, so it can be passed to the New*Client functions.
+type Conn struct {
+	conn         *grpc.ClientConn
+	interceptors []grpc.UnaryClientInterceptor
+}
+
+// Ensure Conn can be used by the generated clients.
+var _ grpc.ClientConnInterface = &Conn{}
+
+// NewConn wraps the given connection. Interceptors are applied in the given order, the first one being the
+// outermost.
+func NewConn(conn *grpc.ClientConn, interceptors ...grpc.UnaryClientInterceptor) *Conn {
+	return &Conn{
+		conn:         conn,
+		interceptors: interceptors,
+	}
+}
+
+// Invoke sends a unary call through the interceptors and then to the wrapped connection.
+func (c *Conn) Invoke(ctx context.Context, method string, args any, reply any, opts ...grpc.CallOption) error {
+	return c.invoke(0, ctx, method, args, reply, opts...)
+}
+
+func (c *Conn) invoke(i int, ctx context.Context, method string, args any, reply any, opts ...grpc.CallOption) error {
+	if i == len(c.interceptors) {
+		return c.conn.Invoke(ctx, method, args, reply, opts...)
+	}
+	next := func(ctx context.Context, method string, args, reply any, _ *grpc.ClientConn, opts ...grpc.CallOption) error {
+		return c.invoke(i+1, ctx, method, args, reply, opts...)
+	}
+	return c.interceptors[i](ctx, method, args, reply, c.conn, next, opts...)
+}
+
+// NewStream opens a stream on the wrapped connection. Streams don't go through the unary interceptors.
+func (c *Conn) NewStream(ctx context.Context, desc *grpc.StreamDesc, method string, opts ...grpc.CallOption) (grpc.ClientStream, error) {
+	return c.conn.NewStream(ctx, desc, method, opts...)
+}
@@ -0,0 +1,58 @@
+/*
+Copyright (c) 2025 Red Hat Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License"); you may not use this file except in compliance with the
+License. You may obtain a copy of the License at
+
+  http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software distributed under the License is distributed on an
+"AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the License for the specific
+language governing permissions and limitations under the License.
+*/
+
+package client
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+	"strings"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/metadata"
+)
+
+// headerNamePattern is the set of characters allowed in the names of ASCII gRPC metadata keys.
+var headerNamePattern = regexp.MustCompile(`^[a-z0-9_.-]+$`)
+
+// CheckHeaderName checks that a header can be sent as outgoing metadata of every call. Names are case insensitive.
+// Binary headers, reserved gRPC headers and the authorization header, which carries the credentials, are rejected.
+func CheckHeaderName(name string) error {
+	name = strings.ToLower(name)
+	switch {
+	case !headerNamePattern.MatchString(name):
+		return fmt.Errorf("header name '%s' must only contain letters, digits, '-', '_' and '.'", name)
+	case strings.HasPrefix(name, "grpc-"):
+		return fmt.Errorf("header name '%s' is reserved by gRPC", name)
+	case strings.HasSuffix(name, "-bin"):
+		return fmt.Errorf("header name '%s' is for binary values, which aren't supported", name)
+	case name == "authorization":
+		return fmt.Errorf("header name '%s' is reserved for the credentials", name)
+	}
+	return nil
+}
+
+// HeadersInterceptor returns a unary interceptor that adds the given headers to the outgoing metadata of every call,
+// for example to identify the calls made by Terraform in the logs of the server.
+func HeadersInterceptor(headers map[string]string) grpc.UnaryClientInterceptor {
+	pairs := make([]string, 0, 2*len(headers))
+	for name, value := range headers {
+		pairs = append(pairs, strings.ToLower(name), value)
+	}
+	return func(ctx context.Context, method string, req, reply any, cc *grpc.ClientConn,
+		invoker grpc.UnaryInvoker, opts ...grpc.CallOption) error {
+		ctx = metadata.AppendToOutgoingContext(ctx, pairs...)
+		return invoker(ctx, method, req, reply, cc, opts...)
+	}
+}
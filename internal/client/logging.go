@@ -0,0 +1,185 @@
+/*
+Copyright (c) 2025 Red Hat Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License"); you may not use this file except in compliance with the
+License. You may obtain a copy of the License at
+
+  http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software distributed under the License is distributed on an
+"AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the License for the specific
+language governing permissions and limitations under the License.
+*/
+
+package client
+
+import (
+	"context"
+	"strings"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/encoding/protojson"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protoreflect"
+)
+
+// redacted replaces sensitive values in the logs.
+const redacted = "REDACTED"
+
+// sensitiveNames are the names of metadata keys, message fields and map keys whose values are never logged.
+// Names ending with one of sensitiveSuffixes are considered sensitive as well.
+var sensitiveNames = map[string]bool{
+	"authorization": true,
+	"cookie":        true,
+	"kubeconfig":    true,
+	"password":      true,
+	"private_key":   true,
+	"pull_secret":   true,
+	"secret":        true,
+	"token":         true,
+}
+
+var sensitiveSuffixes = []string{
+	"_password",
+	"_secret",
+	"_token",
+	"_key",
+}
+
+// LoggingInterceptor returns a unary interceptor that logs each call at debug level: the method, the outgoing
+// metadata, the request and response messages, the duration and the resulting status code. Values that look
+// sensitive (authorization headers, passwords, secrets, tokens, kubeconfigs, ...) are redacted before logging,
+// including template parameters with such names.
+func LoggingInterceptor() grpc.UnaryClientInterceptor {
+	return func(ctx context.Context, method string, req, reply any, cc *grpc.ClientConn,
+		invoker grpc.UnaryInvoker, opts ...grpc.CallOption) error {
+		fields := map[string]interface{}{
+			"method":  method,
+			"request": redactMessage(req),
+		}
+		if md, ok := metadata.FromOutgoingContext(ctx); ok {
+			fields["metadata"] = redactMetadata(md)
+		}
+		tflog.Debug(ctx, "Sending request", fields)
+
+		start := time.Now()
+		err := invoker(ctx, method, req, reply, cc, opts...)
+		fields = map[string]interface{}{
+			"method":   method,
+			"code":     status.Code(err).String(),
+			"duration": time.Since(start).Round(time.Millisecond).String(),
+		}
+		if err != nil {
+			fields["error"] = err.Error()
+		} else {
+			fields["response"] = redactMessage(reply)
+		}
+		tflog.Debug(ctx, "Received response", fields)
+		return err
+	}
+}
+
+// isSensitiveName checks if the value of a metadata key, field or map key with the given name must be redacted.
+func isSensitiveName(name string) bool {
+	name = strings.ReplaceAll(strings.ToLower(name), "-", "_")
+	if sensitiveNames[name] {
+		return true
+	}
+	for _, suffix := range sensitiveSuffixes {
+		if strings.HasSuffix(name, suffix) {
+			return true
+		}
+	}
+	return false
+}
+
+// redactMetadata returns a copy of the metadata where the values of sensitive keys are replaced.
+func redactMetadata(md metadata.MD) map[string][]string {
+	result := make(map[string][]string, len(md))
+	for key, values := range md {
+		if isSensitiveName(key) {
+			result[key] = []string{redacted}
+			continue
+		}
+		result[key] = values
+	}
+	return result
+}
+
+// redactMessage renders a message as JSON, with the values of sensitive fields and map entries replaced. The
+// message itself isn't modified.
+func redactMessage(value any) string {
+	message, ok := value.(proto.Message)
+	if !ok {
+		return ""
+	}
+	clone := proto.Clone(message)
+	redactFields(clone.ProtoReflect())
+	data, err := protojson.Marshal(clone)
+	if err != nil {
+		return ""
+	}
+	return string(data)
+}
+
+// redactFields replaces, in place, the values of the sensitive fields of the message and of its nested messages.
+// Sensitive string fields are replaced with a marker, and other sensitive fields are cleared.
+func redactFields(message protoreflect.Message) {
+	// The message can't be modified while its fields are iterated, so sensitive fields are collected first
+	var sensitive []protoreflect.FieldDescriptor
+	message.Range(func(field protoreflect.FieldDescriptor, value protoreflect.Value) bool {
+		switch {
+		case isSensitiveName(string(field.Name())):
+			sensitive = append(sensitive, field)
+		case field.IsMap():
+			redactMap(field, value.Map())
+		case field.IsList():
+			if field.Message() != nil {
+				list := value.List()
+				for i := 0; i < list.Len(); i++ {
+					redactFields(list.Get(i).Message())
+				}
+			}
+		case field.Message() != nil:
+			redactFields(value.Message())
+		}
+		return true
+	})
+	for _, field := range sensitive {
+		if field.Kind() == protoreflect.StringKind && field.Cardinality() != protoreflect.Repeated {
+			message.Set(field, protoreflect.ValueOfString(redacted))
+		} else {
+			message.Clear(field)
+		}
+	}
+}
+
+// redactMap redacts the entries of a map field whose keys are sensitive, like template parameters holding secrets,
+// and the sensitive fields of the message values of the other entries.
+func redactMap(field protoreflect.FieldDescriptor, entries protoreflect.Map) {
+	valueField := field.MapValue()
+	var sensitive []protoreflect.MapKey
+	entries.Range(func(key protoreflect.MapKey, value protoreflect.Value) bool {
+		switch {
+		case field.MapKey().Kind() == protoreflect.StringKind && isSensitiveName(key.String()):
+			sensitive = append(sensitive, key)
+		case valueField.Message() != nil:
+			redactFields(value.Message())
+		}
+		return true
+	})
+	for _, key := range sensitive {
+		switch {
+		case valueField.Kind() == protoreflect.StringKind:
+			entries.Set(key, protoreflect.ValueOfString(redacted))
+		case valueField.Message() != nil:
+			entries.Set(key, protoreflect.ValueOfMessage(entries.Get(key).Message().Type().New()))
+		default:
+			entries.Clear(key)
+		}
+	}
+}
@@ -0,0 +1,155 @@
+/*
+Copyright (c) 2025 Red Hat Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License"); you may not use this file except in compliance with the
+License. You may obtain a copy of the License at
+
+  http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software distributed under the License is distributed on an
+"AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the License for the specific
+language governing permissions and limitations under the License.
+*/
+
+package client
+
+import (
+	"bytes"
+	"context"
+	"strings"
+	"testing"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protodesc"
+	"google.golang.org/protobuf/reflect/protoreflect"
+	"google.golang.org/protobuf/types/descriptorpb"
+	"google.golang.org/protobuf/types/dynamicpb"
+	"google.golang.org/protobuf/types/known/anypb"
+	"google.golang.org/protobuf/types/known/wrapperspb"
+
+	"github.com/hashicorp/terraform-plugin-log/tflogtest"
+
+	fulfillmentv1 "github.com/innabox/fulfillment-common/api/fulfillment/v1"
+)
+
+// credentialsMessage returns a message with the given string field set to the given value. The API has no messages
+// with fields like token, so the message type is built on the fly with a field of each given name.
+func credentialsMessage(t *testing.T, field, value string, names ...string) proto.Message {
+	t.Helper()
+	message := &descriptorpb.DescriptorProto{
+		Name: proto.String("Credentials"),
+	}
+	for i, name := range names {
+		message.Field = append(message.Field, &descriptorpb.FieldDescriptorProto{
+			Name:     proto.String(name),
+			JsonName: proto.String(name),
+			Number:   proto.Int32(int32(i + 1)),
+			Type:     descriptorpb.FieldDescriptorProto_TYPE_STRING.Enum(),
+			Label:    descriptorpb.FieldDescriptorProto_LABEL_OPTIONAL.Enum(),
+		})
+	}
+	file, err := protodesc.NewFile(&descriptorpb.FileDescriptorProto{
+		Name:        proto.String("logging_test.proto"),
+		Package:     proto.String("test"),
+		Syntax:      proto.String("proto3"),
+		MessageType: []*descriptorpb.DescriptorProto{message},
+	}, nil)
+	if err != nil {
+		t.Fatalf("failed to build the message type: %v", err)
+	}
+	descriptor := file.Messages().ByName("Credentials")
+	result := dynamicpb.NewMessage(descriptor)
+	result.Set(descriptor.Fields().ByName(protoreflect.Name(field)), protoreflect.ValueOfString(value))
+	return result
+}
+
+// stringParameter returns a template parameter holding the given string.
+func stringParameter(t *testing.T, value string) *anypb.Any {
+	t.Helper()
+	result, err := anypb.New(wrapperspb.String(value))
+	if err != nil {
+		t.Fatalf("failed to wrap parameter: %v", err)
+	}
+	return result
+}
+
+func TestLoggingInterceptorRedactsSecrets(t *testing.T) {
+	const secret = "s3cr3t-value"
+	tests := []struct {
+		name     string
+		metadata metadata.MD
+		request  proto.Message
+		response proto.Message
+	}{
+		{
+			name:     "Authorization metadata",
+			metadata: metadata.Pairs("authorization", "Bearer "+secret),
+			request:  &fulfillmentv1.ClustersGetRequest{Id: "my-cluster"},
+			response: &fulfillmentv1.ClustersGetResponse{},
+		},
+		{
+			name:     "Pull secret field",
+			request:  credentialsMessage(t, "pull_secret", secret, "name", "pull_secret"),
+			response: &fulfillmentv1.ClustersGetResponse{},
+		},
+		{
+			name:     "Token field",
+			request:  &fulfillmentv1.ClustersGetRequest{Id: "my-cluster"},
+			response: credentialsMessage(t, "token", secret, "name", "token"),
+		},
+		{
+			name:     "Field with a sensitive suffix",
+			request:  credentialsMessage(t, "refresh_token", secret, "refresh_token"),
+			response: &fulfillmentv1.ClustersGetResponse{},
+		},
+		{
+			name: "Sensitive template parameters",
+			request: &fulfillmentv1.ComputeInstancesCreateRequest{
+				Object: &fulfillmentv1.ComputeInstance{
+					Spec: &fulfillmentv1.ComputeInstanceSpec{
+						Template: "small",
+						TemplateParameters: map[string]*anypb.Any{
+							"cpu_count":     stringParameter(t, "2"),
+							"pull_secret":   stringParameter(t, secret),
+							"root_password": stringParameter(t, secret),
+							"ssh_key":       stringParameter(t, secret),
+						},
+					},
+				},
+			},
+			response: &fulfillmentv1.ComputeInstancesCreateResponse{},
+		},
+		{
+			name:     "Kubeconfig",
+			request:  &fulfillmentv1.ClustersGetKubeconfigRequest{Id: "my-cluster"},
+			response: &fulfillmentv1.ClustersGetKubeconfigResponse{Kubeconfig: secret},
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			var output bytes.Buffer
+			ctx := tflogtest.RootLogger(context.Background(), &output)
+			if test.metadata != nil {
+				ctx = metadata.NewOutgoingContext(ctx, test.metadata)
+			}
+			invoker := func(ctx context.Context, method string, req, reply any, cc *grpc.ClientConn,
+				opts ...grpc.CallOption) error {
+				return nil
+			}
+
+			err := LoggingInterceptor()(ctx, "/test.Service/Method", test.request, test.response, nil, invoker)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			logs := output.String()
+			if !strings.Contains(logs, "/test.Service/Method") {
+				t.Fatalf("expected the call to be logged, got %q", logs)
+			}
+			if strings.Contains(logs, secret) {
+				t.Errorf("expected the secret to be redacted, got %q", logs)
+			}
+		})
+	}
+}
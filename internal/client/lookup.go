@@ -0,0 +1,59 @@
+/*
+Copyright (c) 2025 Red Hat Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License"); you may not use this file except in compliance with the
+License. You may obtain a copy of the License at
+
+  http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software distributed under the License is distributed on an
+"AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the License for the specific
+language governing permissions and limitations under the License.
+*/
+
+package client
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	sharedv1 "github.com/innabox/fulfillment-common/api/shared/v1"
+)
+
+// Named is implemented by the API objects that have a name in their metadata.
+type Named interface {
+	GetId() string
+	GetMetadata() *sharedv1.Metadata
+}
+
+// NameFilter returns the filter of a List request that selects the objects with the given name.
+func NameFilter(name string) string {
+	return fmt.Sprintf("this.metadata.name == %s", strconv.Quote(name))
+}
+
+// SelectByName returns the only object of the list whose name is exactly the given one. The kind of the objects is
+// used in the error returned when there is no such object or more than one, which lists their identifiers so that the
+// user can pick one.
+func SelectByName[T Named](kind, name string, items []T) (T, error) {
+	var matches []T
+	for _, item := range items {
+		if item.GetMetadata().GetName() == name {
+			matches = append(matches, item)
+		}
+	}
+	var zero T
+	switch len(matches) {
+	case 0:
+		return zero, fmt.Errorf("no %s named '%s' found", kind, name)
+	case 1:
+		return matches[0], nil
+	default:
+		ids := make([]string, len(matches))
+		for i, match := range matches {
+			ids[i] = match.GetId()
+		}
+		return zero, fmt.Errorf("found %d %ss named '%s', use the identifier instead: %s",
+			len(matches), kind, name, strings.Join(ids, ", "))
+	}
+}
@@ -0,0 +1,87 @@
+/*
+Copyright (c) 2025 Red Hat Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License"); you may not use this file except in compliance with the
+License. You may obtain a copy of the License at
+
+  http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software distributed under the License is distributed on an
+"AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the License for the specific
+language governing permissions and limitations under the License.
+*/
+
+package client
+
+import (
+	"context"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/connectivity"
+)
+
+// DefaultReconnectTimeout is the default maximum time a call waits for the connection to become ready again
+// before it is sent anyway.
+const DefaultReconnectTimeout = 30 * time.Second
+
+// ReconnectInterceptor returns a unary interceptor that checks the state of the channel before each call. When it
+// isn't ready, for example because the backend was restarted and the channel is in TRANSIENT_FAILURE, the
+// interceptor asks it to reconnect immediately and waits up to the given timeout for it to become ready. The call is
+// then sent regardless, so that if the backend is still unreachable the caller gets the usual error.
+func ReconnectInterceptor(timeout time.Duration) grpc.UnaryClientInterceptor {
+	return func(ctx context.Context, method string, req, reply any, cc *grpc.ClientConn,
+		invoker grpc.UnaryInvoker, opts ...grpc.CallOption) error {
+		if cc.GetState() != connectivity.Ready {
+			waitForReady(ctx, cc, timeout)
+		}
+		return invoker(ctx, method, req, reply, cc, opts...)
+	}
+}
+
+// waitForReady triggers a reconnection of the channel and waits until it is ready, the timeout expires or the
+// context is cancelled.
+func waitForReady(ctx context.Context, cc *grpc.ClientConn, timeout time.Duration) {
+	waitCtx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+
+	start := time.Now()
+	state := cc.GetState()
+	tflog.Debug(ctx, "Connection isn't ready, reconnecting", map[string]interface{}{
+		"state": state.String(),
+	})
+	for state != connectivity.Ready {
+		switch state {
+		case connectivity.Shutdown:
+			return
+		case connectivity.Idle:
+			cc.Connect()
+		case connectivity.TransientFailure:
+			// Skip the remaining backoff, the backend may be back already
+			cc.ResetConnectBackoff()
+		}
+		if !cc.WaitForStateChange(waitCtx, state) {
+			tflog.Debug(ctx, "Connection didn't become ready in time", map[string]interface{}{
+				"state":   cc.GetState().String(),
+				"timeout": timeout.String(),
+			})
+			return
+		}
+		state = cc.GetState()
+	}
+	tflog.Debug(ctx, "Connection is ready again", map[string]interface{}{
+		"elapsed": time.Since(start).Round(time.Millisecond).String(),
+	})
+}
+
+// WaitForReadyInterceptor returns a unary interceptor that adds the gRPC wait-for-ready call option to every call.
+// Instead of failing immediately when the channel is in TRANSIENT_FAILURE, calls are queued until the connection is
+// up again. They still fail when their context is cancelled or its deadline expires.
+func WaitForReadyInterceptor() grpc.UnaryClientInterceptor {
+	return func(ctx context.Context, method string, req, reply any, cc *grpc.ClientConn,
+		invoker grpc.UnaryInvoker, opts ...grpc.CallOption) error {
+		opts = append(opts, grpc.WaitForReady(true))
+		return invoker(ctx, method, req, reply, cc, opts...)
+	}
+}
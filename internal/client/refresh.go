@@ -0,0 +1,53 @@
+/*
+Copyright (c) 2025 Red Hat Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License"); you may not use this file except in compliance with the
+License. You may obtain a copy of the License at
+
+  http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software distributed under the License is distributed on an
+"AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the License for the specific
+language governing permissions and limitations under the License.
+*/
+
+package client
+
+import (
+	"context"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	"github.com/innabox/fulfillment-common/auth"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// TokenRefreshInterceptor returns a unary interceptor that retries once a call rejected with Unauthenticated, after
+// marking the access token kept in the given store as expired. The token source that uses the store then refreshes
+// the token, or requests a new one, when the retry asks for it, instead of sending again the token that the server
+// has just rejected. This covers tokens that the server considers expired before the time the client expects, for
+// example because of clock skew or revocation.
+func TokenRefreshInterceptor(store auth.TokenStore) grpc.UnaryClientInterceptor {
+	return func(ctx context.Context, method string, req, reply any, cc *grpc.ClientConn,
+		invoker grpc.UnaryInvoker, opts ...grpc.CallOption) error {
+		err := invoker(ctx, method, req, reply, cc, opts...)
+		if status.Code(err) != codes.Unauthenticated {
+			return err
+		}
+		token, loadErr := store.Load(ctx)
+		if loadErr != nil || token == nil || token.Access == "" {
+			return err
+		}
+		expired := *token
+		expired.Expiry = time.Now()
+		if saveErr := store.Save(ctx, &expired); saveErr != nil {
+			return err
+		}
+		tflog.Debug(ctx, "Call was rejected as unauthenticated, retrying with a new token", map[string]interface{}{
+			"method": method,
+		})
+		return invoker(ctx, method, req, reply, cc, opts...)
+	}
+}
@@ -0,0 +1,145 @@
+/*
+Copyright (c) 2025 Red Hat Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License"); you may not use this file except in compliance with the
+License. You may obtain a copy of the License at
+
+  http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software distributed under the License is distributed on an
+"AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the License for the specific
+language governing permissions and limitations under the License.
+*/
+
+package client
+
+import (
+	"context"
+	"io"
+	"log/slog"
+	"slices"
+	"testing"
+	"time"
+
+	"github.com/innabox/fulfillment-common/auth"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// fakeTokenSource behaves like the OAuth token source: it returns the token kept in the store while it doesn't expire
+// soon, and otherwise issues the next of the given access tokens and saves it in the store.
+type fakeTokenSource struct {
+	store  auth.TokenStore
+	tokens []string
+}
+
+func (s *fakeTokenSource) Token(ctx context.Context) (*auth.Token, error) {
+	loaded, err := s.store.Load(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if loaded != nil && time.Until(loaded.Expiry) > 30*time.Second {
+		return loaded, nil
+	}
+	if len(s.tokens) == 0 {
+		return nil, status.Error(codes.Unauthenticated, "no token available")
+	}
+	token := &auth.Token{
+		Access: s.tokens[0],
+		Expiry: time.Now().Add(time.Hour),
+	}
+	s.tokens = s.tokens[1:]
+	return token, s.store.Save(ctx, token)
+}
+
+// authenticatingInvoker returns an invoker that gets a token from the given source for each call, like the credentials
+// of the connection, and rejects the calls made with the given expired token. The tokens sent are stored in the given
+// slice.
+func authenticatingInvoker(source auth.TokenSource, expired string, sent *[]string) grpc.UnaryInvoker {
+	return func(ctx context.Context, method string, req, reply any, cc *grpc.ClientConn, opts ...grpc.CallOption) error {
+		token, err := source.Token(ctx)
+		if err != nil {
+			return err
+		}
+		*sent = append(*sent, token.Access)
+		if token.Access == expired {
+			return status.Error(codes.Unauthenticated, "token is expired")
+		}
+		return nil
+	}
+}
+
+func TestTokenRefreshInterceptor(t *testing.T) {
+	tests := []struct {
+		name    string
+		tokens  []string
+		expired string
+		sent    []string
+		code    codes.Code
+	}{
+		{
+			name:   "Valid token",
+			tokens: []string{"fresh"},
+			sent:   []string{"fresh"},
+			code:   codes.OK,
+		},
+		{
+			name:    "Expired token is refreshed",
+			tokens:  []string{"expired", "fresh"},
+			expired: "expired",
+			sent:    []string{"expired", "fresh"},
+			code:    codes.OK,
+		},
+		{
+			name:    "Retried only once",
+			tokens:  []string{"expired", "expired", "fresh"},
+			expired: "expired",
+			sent:    []string{"expired", "expired"},
+			code:    codes.Unauthenticated,
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			ctx := context.Background()
+			store, err := auth.NewMemoryTokenStore().
+				SetLogger(slog.New(slog.NewTextHandler(io.Discard, nil))).
+				Build()
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			source := &fakeTokenSource{store: store, tokens: test.tokens}
+
+			// The server rejects the first token before the time the client expects it to expire
+			var sent []string
+			interceptor := TokenRefreshInterceptor(store)
+			err = interceptor(ctx, "/fulfillment.v1.Clusters/Get", nil, nil, nil,
+				authenticatingInvoker(source, test.expired, &sent))
+			if status.Code(err) != test.code {
+				t.Errorf("expected %s, got %v", test.code, err)
+			}
+			if !slices.Equal(sent, test.sent) {
+				t.Errorf("expected tokens %v to be sent, got %v", test.sent, sent)
+			}
+		})
+	}
+}
+
+func TestTokenRefreshInterceptorDoesNotRetryOtherErrors(t *testing.T) {
+	store, err := auth.NewMemoryTokenStore().
+		SetLogger(slog.New(slog.NewTextHandler(io.Discard, nil))).
+		Build()
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	calls := 0
+	interceptor := TokenRefreshInterceptor(store)
+	err = interceptor(context.Background(), "/fulfillment.v1.Clusters/Get", nil, nil, nil,
+		fakeInvoker(&calls, status.Error(codes.PermissionDenied, "denied")))
+	if status.Code(err) != codes.PermissionDenied {
+		t.Errorf("expected PermissionDenied, got %v", err)
+	}
+	if calls != 1 {
+		t.Errorf("expected 1 call, got %d", calls)
+	}
+}
@@ -0,0 +1,145 @@
+/*
+Copyright (c) 2025 Red Hat Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License"); you may not use this file except in compliance with the
+License. You may obtain a copy of the License at
+
+  http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software distributed under the License is distributed on an
+"AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the License for the specific
+language governing permissions and limitations under the License.
+*/
+
+package client
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+)
+
+const (
+	// DefaultMaxRetries is the default number of times a call that failed with a transient error is retried.
+	DefaultMaxRetries = 3
+	// RetryInitialBackoff is the time waited before the first retry. It doubles with each retry.
+	RetryInitialBackoff = time.Second
+	// DefaultRetryMaxBackoff is the default longest time waited between two retries.
+	DefaultRetryMaxBackoff = 30 * time.Second
+)
+
+// RetryInterceptor returns a unary interceptor that retries calls failing with a transient error, Unavailable,
+// ResourceExhausted or a DeadlineExceeded set by the TimeoutInterceptor, up to the given number of times with an
+// exponential backoff capped at maxBackoff. Only calls that are safe to repeat are retried: reads, lists and deletes.
+// Creates and updates could be applied twice, so they fail on the first error. A retry that couldn't start before the
+// deadline of the context isn't attempted, and the last error is returned right away.
+//
+// When the server limits the rate of the calls, it can tell how long to wait before the next one, either with a
+// RetryInfo detail of the ResourceExhausted error or with a retry-after trailer holding a number of seconds or an HTTP
+// date. That delay is used instead of the backoff.
+func RetryInterceptor(maxRetries int, maxBackoff time.Duration) grpc.UnaryClientInterceptor {
+	return func(ctx context.Context, method string, req, reply any, cc *grpc.ClientConn,
+		invoker grpc.UnaryInvoker, opts ...grpc.CallOption) error {
+		if !isRetryableMethod(method) {
+			return invoker(ctx, method, req, reply, cc, opts...)
+		}
+		var trailer metadata.MD
+		opts = append(opts[:len(opts):len(opts)], grpc.Trailer(&trailer))
+		err := invoker(ctx, method, req, reply, cc, opts...)
+		backoff := min(RetryInitialBackoff, maxBackoff)
+		attempt := 1
+		for ; attempt <= maxRetries && isRetryableError(ctx, err); attempt++ {
+			delay := backoff
+			if hint, ok := retryDelay(err, trailer); ok {
+				delay = hint
+			}
+			if deadline, ok := ctx.Deadline(); ok && time.Until(deadline) <= delay {
+				return err
+			}
+			tflog.Debug(ctx, "Call failed with a transient error, retrying", map[string]interface{}{
+				"method":  method,
+				"code":    status.Code(err).String(),
+				"attempt": attempt,
+				"backoff": delay.String(),
+			})
+			select {
+			case <-ctx.Done():
+				return err
+			case <-time.After(delay):
+			}
+			backoff = min(2*backoff, maxBackoff)
+			err = invoker(ctx, method, req, reply, cc, opts...)
+		}
+		if attempt > 1 && isRetryableError(ctx, err) {
+			return retriesExhaustedError(err, attempt)
+		}
+		return err
+	}
+}
+
+// retryDelay returns the delay before the next call requested by the server with a ResourceExhausted error, and
+// whether there is one.
+func retryDelay(err error, trailer metadata.MD) (time.Duration, bool) {
+	if status.Code(err) != codes.ResourceExhausted {
+		return 0, false
+	}
+	for _, detail := range status.Convert(err).Details() {
+		if info, ok := detail.(*errdetails.RetryInfo); ok && info.GetRetryDelay() != nil {
+			return max(info.GetRetryDelay().AsDuration(), 0), true
+		}
+	}
+	values := trailer.Get("retry-after")
+	if len(values) == 0 {
+		return 0, false
+	}
+	value := strings.TrimSpace(values[0])
+	if seconds, err := strconv.Atoi(value); err == nil && seconds >= 0 {
+		return time.Duration(seconds) * time.Second, true
+	}
+	if date, err := http.ParseTime(value); err == nil {
+		return max(time.Until(date), 0), true
+	}
+	return 0, false
+}
+
+// retriesExhaustedError returns the error of the last of the given number of attempts, with a message that tells that
+// the call was retried. The code and the details of the error are kept.
+func retriesExhaustedError(err error, attempts int) error {
+	proto := status.Convert(err).Proto()
+	reason := "the call failed"
+	if status.Code(err) == codes.ResourceExhausted {
+		reason = "the server is limiting the rate of calls"
+	}
+	proto.Message = fmt.Sprintf("%s (%s %d times, giving up)", proto.GetMessage(), reason, attempts)
+	return status.ErrorProto(proto)
+}
+
+// isRetryableMethod checks if the method, a full gRPC method name like /fulfillment.v1.Clusters/Get, can be sent
+// again without side effects.
+func isRetryableMethod(method string) bool {
+	name := method[strings.LastIndex(method, "/")+1:]
+	return strings.HasPrefix(name, "Get") || strings.HasPrefix(name, "List") || name == "Delete"
+}
+
+// isRetryableError checks if the error is one that is likely to go away by itself. A deadline is only retried when it
+// is the deadline of the single call, not the one of the context of the caller.
+func isRetryableError(ctx context.Context, err error) bool {
+	switch status.Code(err) {
+	case codes.Unavailable, codes.ResourceExhausted:
+		return true
+	case codes.DeadlineExceeded:
+		return ctx.Err() == nil
+	default:
+		return false
+	}
+}
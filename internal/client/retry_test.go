@@ -0,0 +1,186 @@
+/*
+Copyright (c) 2025 Red Hat Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License"); you may not use this file except in compliance with the
+License. You may obtain a copy of the License at
+
+  http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software distributed under the License is distributed on an
+"AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the License for the specific
+language governing permissions and limitations under the License.
+*/
+
+package client
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"time"
+
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/durationpb"
+)
+
+// fakeInvoker returns an invoker that fails with the given errors, one per call, and then succeeds. The number of
+// calls is stored in the given counter.
+func fakeInvoker(calls *int, errs ...error) grpc.UnaryInvoker {
+	return func(ctx context.Context, method string, req, reply any, cc *grpc.ClientConn, opts ...grpc.CallOption) error {
+		*calls++
+		if *calls <= len(errs) {
+			return errs[*calls-1]
+		}
+		return nil
+	}
+}
+
+func TestRetryInterceptorDoesNotRetryPastDeadline(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.Background(), 100*time.Millisecond)
+	defer cancel()
+
+	calls := 0
+	interceptor := RetryInterceptor(3, DefaultRetryMaxBackoff)
+	start := time.Now()
+	err := interceptor(ctx, "/fulfillment.v1.Clusters/Get", nil, nil, nil,
+		fakeInvoker(&calls, status.Error(codes.Unavailable, "down")))
+	if status.Code(err) != codes.Unavailable {
+		t.Errorf("expected Unavailable, got %v", err)
+	}
+	if calls != 1 {
+		t.Errorf("expected 1 call, got %d", calls)
+	}
+	if elapsed := time.Since(start); elapsed > 50*time.Millisecond {
+		t.Errorf("expected to give up right away, waited %s", elapsed)
+	}
+}
+
+func TestRetryInterceptorDoesNotRetryCreates(t *testing.T) {
+	calls := 0
+	interceptor := RetryInterceptor(3, DefaultRetryMaxBackoff)
+	err := interceptor(context.Background(), "/fulfillment.v1.Clusters/Create", nil, nil, nil,
+		fakeInvoker(&calls, status.Error(codes.Unavailable, "down")))
+	if status.Code(err) != codes.Unavailable {
+		t.Errorf("expected Unavailable, got %v", err)
+	}
+	if calls != 1 {
+		t.Errorf("expected 1 call, got %d", calls)
+	}
+}
+
+// rateLimitedInvoker returns an invoker that fails the given number of times with ResourceExhausted and a
+// retry-after trailer, and then succeeds. The number of calls is stored in the given counter.
+func rateLimitedInvoker(calls *int, failures int, retryAfter string) grpc.UnaryInvoker {
+	return func(ctx context.Context, method string, req, reply any, cc *grpc.ClientConn, opts ...grpc.CallOption) error {
+		*calls++
+		if *calls > failures {
+			return nil
+		}
+		for _, opt := range opts {
+			if trailer, ok := opt.(grpc.TrailerCallOption); ok {
+				*trailer.TrailerAddr = metadata.Pairs("retry-after", retryAfter)
+			}
+		}
+		return status.Error(codes.ResourceExhausted, "too many requests")
+	}
+}
+
+func TestRetryInterceptorHonorsRetryAfter(t *testing.T) {
+	calls := 0
+	interceptor := RetryInterceptor(3, DefaultRetryMaxBackoff)
+	start := time.Now()
+	err := interceptor(context.Background(), "/fulfillment.v1.Clusters/Get", nil, nil, nil,
+		rateLimitedInvoker(&calls, 1, "0"))
+	if err != nil {
+		t.Fatalf("expected success, got %v", err)
+	}
+	if calls != 2 {
+		t.Errorf("expected 2 calls, got %d", calls)
+	}
+
+	// The standard backoff would have waited for a second
+	if elapsed := time.Since(start); elapsed >= RetryInitialBackoff {
+		t.Errorf("expected the retry-after delay to be used, waited %s", elapsed)
+	}
+}
+
+func TestRetryInterceptorReportsExhaustedRetries(t *testing.T) {
+	calls := 0
+	interceptor := RetryInterceptor(2, DefaultRetryMaxBackoff)
+	err := interceptor(context.Background(), "/fulfillment.v1.Clusters/List", nil, nil, nil,
+		rateLimitedInvoker(&calls, 5, "0"))
+	if status.Code(err) != codes.ResourceExhausted {
+		t.Fatalf("expected ResourceExhausted, got %v", err)
+	}
+	if calls != 3 {
+		t.Errorf("expected 3 calls, got %d", calls)
+	}
+	message := status.Convert(err).Message()
+	if !strings.HasPrefix(message, "too many requests") || !strings.Contains(message, "limiting the rate of calls 3 times") {
+		t.Errorf("unexpected message %q", message)
+	}
+}
+
+func TestRetryDelay(t *testing.T) {
+	withRetryInfo, err := status.New(codes.ResourceExhausted, "slow down").WithDetails(&errdetails.RetryInfo{
+		RetryDelay: durationpb.New(2 * time.Second),
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	tests := []struct {
+		name     string
+		err      error
+		trailer  metadata.MD
+		expected time.Duration
+		ok       bool
+	}{
+		{
+			name:     "Retry info",
+			err:      withRetryInfo.Err(),
+			expected: 2 * time.Second,
+			ok:       true,
+		},
+		{
+			name:     "Retry-after seconds",
+			err:      status.Error(codes.ResourceExhausted, "slow down"),
+			trailer:  metadata.Pairs("retry-after", "5"),
+			expected: 5 * time.Second,
+			ok:       true,
+		},
+		{
+			name:     "Retry-after date in the past",
+			err:      status.Error(codes.ResourceExhausted, "slow down"),
+			trailer:  metadata.Pairs("retry-after", "Wed, 21 Oct 2015 07:28:00 GMT"),
+			expected: 0,
+			ok:       true,
+		},
+		{
+			name:    "Invalid retry-after",
+			err:     status.Error(codes.ResourceExhausted, "slow down"),
+			trailer: metadata.Pairs("retry-after", "soon"),
+		},
+		{
+			name:    "No hint",
+			err:     status.Error(codes.ResourceExhausted, "slow down"),
+			trailer: metadata.MD{},
+		},
+		{
+			name:    "Only for rate limits",
+			err:     status.Error(codes.Unavailable, "down"),
+			trailer: metadata.Pairs("retry-after", "5"),
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			delay, ok := retryDelay(test.err, test.trailer)
+			if ok != test.ok || delay != test.expected {
+				t.Errorf("expected %s and %t, got %s and %t", test.expected, test.ok, delay, ok)
+			}
+		})
+	}
+}
@@ -0,0 +1,30 @@
+/*
+Copyright (c) 2025 Red Hat Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License"); you may not use this file except in compliance with the
+License. You may obtain a copy of the License at
+
+  http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software distributed under the License is distributed on an
+"AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the License for the specific
+language governing permissions and limitations under the License.
+*/
+
+package client
+
+import (
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-framework/types"
+
+	sharedv1 "github.com/innabox/fulfillment-common/api/shared/v1"
+)
+
+// CreatedAtValue returns the creation time of an object in RFC 3339 format, or null when the server doesn't report it.
+func CreatedAtValue(metadata *sharedv1.Metadata) types.String {
+	if metadata.GetCreationTimestamp() == nil {
+		return types.StringNull()
+	}
+	return types.StringValue(metadata.GetCreationTimestamp().AsTime().Format(time.RFC3339))
+}
@@ -0,0 +1,141 @@
+/*
+Copyright (c) 2025 Red Hat Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License"); you may not use this file except in compliance with the
+License. You may obtain a copy of the License at
+
+  http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software distributed under the License is distributed on an
+"AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the License for the specific
+language governing permissions and limitations under the License.
+*/
+
+package datasources
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	fulfillmentv1 "github.com/innabox/fulfillment-common/api/fulfillment/v1"
+
+	"github.com/innabox/terraform-provider-osac/internal/client"
+)
+
+// Ensure provider defined types fully satisfy framework interfaces.
+var _ datasource.DataSource = &ClusterCredentialsDataSource{}
+
+func NewClusterCredentialsDataSource() datasource.DataSource {
+	return &ClusterCredentialsDataSource{}
+}
+
+// ClusterCredentialsDataSource defines the data source implementation.
+type ClusterCredentialsDataSource struct {
+	client fulfillmentv1.ClustersClient
+}
+
+// ClusterCredentialsDataSourceModel describes the data source data model.
+type ClusterCredentialsDataSourceModel struct {
+	ID            types.String `tfsdk:"id"`
+	Kubeconfig    types.String `tfsdk:"kubeconfig"`
+	AdminPassword types.String `tfsdk:"admin_password"`
+}
+
+func (d *ClusterCredentialsDataSource) Metadata(ctx context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_cluster_credentials"
+}
+
+func (d *ClusterCredentialsDataSource) Schema(ctx context.Context, req datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Description: "Fetches the credentials of an existing OSAC cluster. The values are sensitive, so they are " +
+			"redacted in the plan output, but they are stored in the state in plain text.",
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				Description: "Unique identifier of the cluster.",
+				Required:    true,
+			},
+			"kubeconfig": schema.StringAttribute{
+				Description: "Admin kubeconfig of the cluster. Null if the backend doesn't provide it.",
+				Computed:    true,
+				Sensitive:   true,
+			},
+			"admin_password": schema.StringAttribute{
+				Description: "Password of the admin user of the cluster. Null if the backend doesn't provide it.",
+				Computed:    true,
+				Sensitive:   true,
+			},
+		},
+	}
+}
+
+func (d *ClusterCredentialsDataSource) Configure(ctx context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	providerData, ok := req.ProviderData.(*client.ProviderData)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Data Source Configure Type",
+			fmt.Sprintf("Expected *client.ProviderData, got: %T", req.ProviderData),
+		)
+		return
+	}
+
+	d.client = providerData.ClustersClient
+}
+
+func (d *ClusterCredentialsDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	var data ClusterCredentialsDataSourceModel
+
+	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	id := data.ID.ValueString()
+
+	data.Kubeconfig = types.StringNull()
+	kubeconfigResp, err := d.client.GetKubeconfig(ctx, &fulfillmentv1.ClustersGetKubeconfigRequest{Id: id})
+	if err == nil {
+		data.Kubeconfig = types.StringValue(kubeconfigResp.Kubeconfig)
+	} else if !credentialsUnavailable(err, id, "kubeconfig", &resp.Diagnostics) {
+		return
+	}
+
+	data.AdminPassword = types.StringNull()
+	passwordResp, err := d.client.GetPassword(ctx, &fulfillmentv1.ClustersGetPasswordRequest{Id: id})
+	if err == nil {
+		data.AdminPassword = types.StringValue(passwordResp.Password)
+	} else if !credentialsUnavailable(err, id, "admin password", &resp.Diagnostics) {
+		return
+	}
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+// credentialsUnavailable reports an error returned while fetching one of the credentials of a cluster. When the
+// backend doesn't implement the call a warning is added and true is returned, so that the other credentials can
+// still be read. Any other error, including a missing cluster, is added as an error and false is returned.
+func credentialsUnavailable(err error, id, what string, diags *diag.Diagnostics) bool {
+	switch status.Code(err) {
+	case codes.Unimplemented:
+		diags.AddWarning(
+			"Cluster credentials not available",
+			fmt.Sprintf("The backend doesn't provide the %s of clusters, it will be null.", what),
+		)
+		return true
+	case codes.NotFound:
+		diags.AddError("Cluster not found", fmt.Sprintf("Cluster %s doesn't exist.", id))
+	default:
+		diags.AddError("Failed to read cluster credentials", fmt.Sprintf("Cluster %s: failed to get %s: %s", id, what, err.Error()))
+	}
+	return false
+}
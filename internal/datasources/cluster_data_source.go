@@ -17,17 +17,23 @@ import (
 	"context"
 	"fmt"
 
+	"github.com/hashicorp/terraform-plugin-framework-timeouts/datasource/timeouts"
+	"github.com/hashicorp/terraform-plugin-framework-validators/datasourcevalidator"
 	"github.com/hashicorp/terraform-plugin-framework/datasource"
 	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/path"
 	"github.com/hashicorp/terraform-plugin-framework/types"
 
 	fulfillmentv1 "github.com/innabox/fulfillment-common/api/fulfillment/v1"
 
 	"github.com/innabox/terraform-provider-osac/internal/client"
+	"github.com/innabox/terraform-provider-osac/internal/waiter"
 )
 
 // Ensure provider defined types fully satisfy framework interfaces.
 var _ datasource.DataSource = &ClusterDataSource{}
+var _ datasource.DataSourceWithValidateConfig = &ClusterDataSource{}
+var _ datasource.DataSourceWithConfigValidators = &ClusterDataSource{}
 
 func NewClusterDataSource() datasource.DataSource {
 	return &ClusterDataSource{}
@@ -36,16 +42,22 @@ func NewClusterDataSource() datasource.DataSource {
 // ClusterDataSource defines the data source implementation.
 type ClusterDataSource struct {
 	client fulfillmentv1.ClustersClient
+	wait   client.WaitSettings
 }
 
 // ClusterDataSourceModel describes the data source data model.
 type ClusterDataSourceModel struct {
-	ID         types.String `tfsdk:"id"`
-	Name       types.String `tfsdk:"name"`
-	Template   types.String `tfsdk:"template"`
-	State      types.String `tfsdk:"state"`
-	ApiURL     types.String `tfsdk:"api_url"`
-	ConsoleURL types.String `tfsdk:"console_url"`
+	ID           types.String   `tfsdk:"id"`
+	Name         types.String   `tfsdk:"name"`
+	CreatedAt    types.String   `tfsdk:"created_at"`
+	Template     types.String   `tfsdk:"template"`
+	State        types.String   `tfsdk:"state"`
+	ApiURL       types.String   `tfsdk:"api_url"`
+	ConsoleURL   types.String   `tfsdk:"console_url"`
+	Conditions   types.List     `tfsdk:"conditions"`
+	WaitForReady types.Bool     `tfsdk:"wait_for_ready"`
+	PollInterval types.String   `tfsdk:"poll_interval"`
+	Timeouts     timeouts.Value `tfsdk:"timeouts"`
 }
 
 func (d *ClusterDataSource) Metadata(ctx context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
@@ -57,11 +69,18 @@ func (d *ClusterDataSource) Schema(ctx context.Context, req datasource.SchemaReq
 		Description: "Fetches information about an existing OSAC cluster.",
 		Attributes: map[string]schema.Attribute{
 			"id": schema.StringAttribute{
-				Description: "Unique identifier of the cluster.",
-				Required:    true,
+				Description: "Unique identifier of the cluster. Exactly one of id or name must be set.",
+				Optional:    true,
+				Computed:    true,
 			},
 			"name": schema.StringAttribute{
-				Description: "Human-friendly name of the cluster.",
+				Description: "Human-friendly name of the cluster. When id isn't set, the cluster is looked up by " +
+					"name, which must match exactly one cluster.",
+				Optional: true,
+				Computed: true,
+			},
+			"created_at": schema.StringAttribute{
+				Description: "Time when the cluster was created, in RFC 3339 format.",
 				Computed:    true,
 			},
 			"template": schema.StringAttribute{
@@ -80,7 +99,64 @@ func (d *ClusterDataSource) Schema(ctx context.Context, req datasource.SchemaReq
 				Description: "URL of the console of the cluster.",
 				Computed:    true,
 			},
+			"conditions": schema.ListNestedAttribute{
+				Description: "Conditions reported in the status of the cluster.",
+				Computed:    true,
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: map[string]schema.Attribute{
+						"type": schema.StringAttribute{
+							Description: "Type of the condition, like 'CLUSTER_CONDITION_TYPE_READY'.",
+							Computed:    true,
+						},
+						"status": schema.StringAttribute{
+							Description: "Status of the condition, like 'CONDITION_STATUS_TRUE'.",
+							Computed:    true,
+						},
+						"reason": schema.StringAttribute{
+							Description: "Machine-readable reason of the last transition of the condition.",
+							Computed:    true,
+						},
+						"message": schema.StringAttribute{
+							Description: "Human-readable details about the last transition of the condition.",
+							Computed:    true,
+						},
+						"last_transition_time": schema.StringAttribute{
+							Description: "Time of the last transition of the condition, in RFC 3339 format.",
+							Computed:    true,
+						},
+					},
+				},
+			},
+			"wait_for_ready": schema.BoolAttribute{
+				Description: "Wait for the cluster to be ready before reading it. Defaults to false.",
+				Optional:    true,
+			},
+			"poll_interval": schema.StringAttribute{
+				Description: "Time between checks of the cluster state while waiting for it to be ready, like '30s'. " +
+					"Must be at least 1s and less than 3m. When not set, the state is checked every 5 to 10 seconds.",
+				Optional: true,
+			},
 		},
+		Blocks: map[string]schema.Block{
+			"timeouts": timeouts.Block(ctx),
+		},
+	}
+}
+
+func (d *ClusterDataSource) ConfigValidators(ctx context.Context) []datasource.ConfigValidator {
+	return []datasource.ConfigValidator{
+		datasourcevalidator.ExactlyOneOf(path.MatchRoot("id"), path.MatchRoot("name")),
+	}
+}
+
+func (d *ClusterDataSource) ValidateConfig(ctx context.Context, req datasource.ValidateConfigRequest, resp *datasource.ValidateConfigResponse) {
+	var pollInterval types.String
+	resp.Diagnostics.Append(req.Config.GetAttribute(ctx, path.Root("poll_interval"), &pollInterval)...)
+	if resp.Diagnostics.HasError() || pollInterval.IsNull() || pollInterval.IsUnknown() {
+		return
+	}
+	if _, err := waiter.ParsePollInterval(pollInterval.ValueString()); err != nil {
+		resp.Diagnostics.AddAttributeError(path.Root("poll_interval"), "Invalid poll interval", err.Error())
 	}
 }
 
@@ -99,6 +175,7 @@ func (d *ClusterDataSource) Configure(ctx context.Context, req datasource.Config
 	}
 
 	d.client = providerData.ClustersClient
+	d.wait = providerData.Wait
 }
 
 func (d *ClusterDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
@@ -109,20 +186,90 @@ func (d *ClusterDataSource) Read(ctx context.Context, req datasource.ReadRequest
 		return
 	}
 
-	getResp, err := d.client.Get(ctx, &fulfillmentv1.ClustersGetRequest{
-		Id: data.ID.ValueString(),
-	})
-	if err != nil {
-		resp.Diagnostics.AddError("Failed to read cluster", err.Error())
-		return
+	// Resolve the identifier from the name if needed
+	if data.ID.IsNull() {
+		found, err := findByName("cluster", data.Name.ValueString(),
+			func(filter string, offset, limit int32) ([]*fulfillmentv1.Cluster, int32, error) {
+				listResp, err := d.client.List(ctx, &fulfillmentv1.ClustersListRequest{
+					Filter: &filter,
+					Offset: &offset,
+					Limit:  &limit,
+				})
+				if err != nil {
+					return nil, 0, err
+				}
+				return listResp.Items, listResp.GetTotal(), nil
+			})
+		if err != nil {
+			resp.Diagnostics.AddAttributeError(path.Root("name"), "Failed to find cluster", err.Error())
+			return
+		}
+		data.ID = types.StringValue(found.Id)
+	}
+
+	var cluster *fulfillmentv1.Cluster
+	if data.WaitForReady.ValueBool() {
+		readTimeout, diags := data.Timeouts.Read(ctx, waiter.DefaultReadTimeout)
+		resp.Diagnostics.Append(diags...)
+		if resp.Diagnostics.HasError() {
+			return
+		}
+
+		pollInterval := d.wait.PollInterval
+		if !data.PollInterval.IsNull() {
+			var err error
+			pollInterval, err = waiter.ParsePollInterval(data.PollInterval.ValueString())
+			if err != nil {
+				resp.Diagnostics.AddAttributeError(path.Root("poll_interval"), "Invalid poll interval", err.Error())
+				return
+			}
+		}
+
+		// Cancel requests in flight, not only the polling, when the read timeout expires
+		ctx, cancel := context.WithTimeout(ctx, readTimeout)
+		defer cancel()
+
+		result, err := waiter.WaitForReady(ctx, waiter.Config{
+			PendingStates: []string{
+				fulfillmentv1.ClusterState_CLUSTER_STATE_UNSPECIFIED.String(),
+				fulfillmentv1.ClusterState_CLUSTER_STATE_PROGRESSING.String(),
+			},
+			TargetStates: []string{
+				fulfillmentv1.ClusterState_CLUSTER_STATE_READY.String(),
+			},
+			RefreshFunc:       d.clusterStateRefreshFunc(ctx, data.ID.ValueString()),
+			Timeout:           readTimeout,
+			FixedPollInterval: pollInterval,
+			MinPollInterval:   d.wait.MinPollInterval,
+			BackoffMultiplier: d.wait.BackoffMultiplier,
+			MaxPollInterval:   d.wait.MaxPollInterval,
+			SlowWarningAfter:  d.wait.SlowWarningAfter,
+		})
+		if err != nil {
+			resp.Diagnostics.AddError(
+				"Error waiting for cluster to be ready",
+				fmt.Sprintf("Cluster %s: %s", data.ID.ValueString(), err.Error()),
+			)
+			return
+		}
+		cluster = result.(*fulfillmentv1.Cluster)
+	} else {
+		getResp, err := d.client.Get(ctx, &fulfillmentv1.ClustersGetRequest{
+			Id: data.ID.ValueString(),
+		})
+		if err != nil {
+			resp.Diagnostics.AddError("Failed to read cluster", err.Error())
+			return
+		}
+		cluster = getResp.Object
 	}
 
-	cluster := getResp.Object
 	data.ID = types.StringValue(cluster.Id)
 
 	if cluster.Metadata != nil {
 		data.Name = types.StringValue(cluster.Metadata.Name)
 	}
+	data.CreatedAt = client.CreatedAtValue(cluster.Metadata)
 
 	if cluster.Spec != nil {
 		data.Template = types.StringValue(cluster.Spec.Template)
@@ -132,7 +279,32 @@ func (d *ClusterDataSource) Read(ctx context.Context, req datasource.ReadRequest
 		data.State = types.StringValue(cluster.Status.State.String())
 		data.ApiURL = types.StringValue(cluster.Status.ApiUrl)
 		data.ConsoleURL = types.StringValue(cluster.Status.ConsoleUrl)
+		data.Conditions = client.ClusterConditionsValue(cluster.Status.Conditions)
+	} else {
+		data.Conditions = types.ListNull(types.ObjectType{AttrTypes: client.ConditionAttrTypes})
 	}
 
 	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
 }
+
+// clusterStateRefreshFunc returns a StateRefreshFunc that fetches the cluster and returns its state.
+func (d *ClusterDataSource) clusterStateRefreshFunc(ctx context.Context, clusterID string) waiter.StateRefreshFunc {
+	return waiter.LogTransitions(ctx, "cluster", clusterID, func() (interface{}, string, error) {
+		getResp, err := d.client.Get(ctx, &fulfillmentv1.ClustersGetRequest{Id: clusterID})
+		if err != nil {
+			return nil, "", fmt.Errorf("failed to get cluster: %w", err)
+		}
+
+		cluster := getResp.Object
+		if cluster.Status == nil {
+			return cluster, fulfillmentv1.ClusterState_CLUSTER_STATE_UNSPECIFIED.String(), nil
+		}
+
+		state := cluster.Status.State
+		if state == fulfillmentv1.ClusterState_CLUSTER_STATE_FAILED {
+			return nil, state.String(), fmt.Errorf("cluster reached FAILED state%s", client.FailureReason(cluster.Status.Conditions))
+		}
+
+		return cluster, state.String(), nil
+	})
+}
@@ -0,0 +1,163 @@
+/*
+Copyright (c) 2025 Red Hat Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License"); you may not use this file except in compliance with the
+License. You may obtain a copy of the License at
+
+  http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software distributed under the License is distributed on an
+"AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the License for the specific
+language governing permissions and limitations under the License.
+*/
+
+package datasources
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+
+	fulfillmentv1 "github.com/innabox/fulfillment-common/api/fulfillment/v1"
+
+	"github.com/innabox/terraform-provider-osac/internal/client"
+)
+
+// Ensure provider defined types fully satisfy framework interfaces.
+var _ datasource.DataSource = &ClusterTemplatesDataSource{}
+
+func NewClusterTemplatesDataSource() datasource.DataSource {
+	return &ClusterTemplatesDataSource{}
+}
+
+// ClusterTemplatesDataSource defines the data source implementation.
+type ClusterTemplatesDataSource struct {
+	client fulfillmentv1.ClusterTemplatesClient
+}
+
+// ClusterTemplatesDataSourceModel describes the data source data model.
+type ClusterTemplatesDataSourceModel struct {
+	FailIfEmpty types.Bool `tfsdk:"fail_if_empty"`
+	Templates   types.List `tfsdk:"templates"`
+}
+
+// ClusterTemplatesItemModel describes a cluster template returned by the data source.
+type ClusterTemplatesItemModel struct {
+	ID          types.String `tfsdk:"id"`
+	Title       types.String `tfsdk:"title"`
+	Description types.String `tfsdk:"description"`
+	Parameters  types.List   `tfsdk:"parameters"`
+}
+
+var clusterTemplatesItemAttrTypes = map[string]attr.Type{
+	"id":          types.StringType,
+	"title":       types.StringType,
+	"description": types.StringType,
+	"parameters":  types.ListType{ElemType: types.ObjectType{AttrTypes: templateParameterAttrTypes}},
+}
+
+func (d *ClusterTemplatesDataSource) Metadata(ctx context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_cluster_templates"
+}
+
+func (d *ClusterTemplatesDataSource) Schema(ctx context.Context, req datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Description: "Fetches information about all the available OSAC cluster templates.",
+		Attributes: map[string]schema.Attribute{
+			"fail_if_empty": failIfEmptyAttribute("cluster templates"),
+			"templates": schema.ListNestedAttribute{
+				Description: "Cluster templates found, in the order returned by the server.",
+				Computed:    true,
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: map[string]schema.Attribute{
+						"id": schema.StringAttribute{
+							Description: "Unique identifier of the cluster template.",
+							Computed:    true,
+						},
+						"title": schema.StringAttribute{
+							Description: "Human-friendly short description of the template.",
+							Computed:    true,
+						},
+						"description": schema.StringAttribute{
+							Description: "Human-friendly long description of the template in Markdown format.",
+							Computed:    true,
+						},
+						"parameters": templateParametersAttribute(),
+					},
+				},
+			},
+		},
+	}
+}
+
+func (d *ClusterTemplatesDataSource) Configure(ctx context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	providerData, ok := req.ProviderData.(*client.ProviderData)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Data Source Configure Type",
+			fmt.Sprintf("Expected *client.ProviderData, got: %T", req.ProviderData),
+		)
+		return
+	}
+
+	d.client = providerData.ClusterTemplatesClient
+}
+
+func (d *ClusterTemplatesDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	var data ClusterTemplatesDataSourceModel
+
+	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	templates, err := listAll(func(offset, limit int32) ([]*fulfillmentv1.ClusterTemplate, int32, error) {
+		listResp, err := d.client.List(ctx, &fulfillmentv1.ClusterTemplatesListRequest{
+			Offset: &offset,
+			Limit:  &limit,
+		})
+		if err != nil {
+			return nil, 0, err
+		}
+		return listResp.Items, listResp.GetTotal(), nil
+	})
+	if err != nil {
+		resp.Diagnostics.AddError("Failed to list cluster templates", err.Error())
+		return
+	}
+
+	items := make([]ClusterTemplatesItemModel, 0, len(templates))
+	for _, template := range templates {
+		parametersValue, diags := types.ListValueFrom(ctx, types.ObjectType{AttrTypes: templateParameterAttrTypes},
+			templateParameterModels(template.Parameters))
+		resp.Diagnostics.Append(diags...)
+		items = append(items, ClusterTemplatesItemModel{
+			ID:          types.StringValue(template.Id),
+			Title:       types.StringValue(template.Title),
+			Description: types.StringValue(template.Description),
+			Parameters:  parametersValue,
+		})
+	}
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	checkNotEmpty(data.FailIfEmpty, "cluster templates", len(items), &resp.Diagnostics)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	templatesValue, diags := types.ListValueFrom(ctx, types.ObjectType{AttrTypes: clusterTemplatesItemAttrTypes}, items)
+	resp.Diagnostics.Append(diags...)
+	data.Templates = templatesValue
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
@@ -0,0 +1,319 @@
+/*
+Copyright (c) 2025 Red Hat Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License"); you may not use this file except in compliance with the
+License. You may obtain a copy of the License at
+
+  http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software distributed under the License is distributed on an
+"AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the License for the specific
+language governing permissions and limitations under the License.
+*/
+
+package datasources
+
+import (
+	"context"
+	"fmt"
+	"sync"
+
+	"github.com/hashicorp/terraform-plugin-framework-validators/datasourcevalidator"
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	fulfillmentv1 "github.com/innabox/fulfillment-common/api/fulfillment/v1"
+
+	"github.com/innabox/terraform-provider-osac/internal/client"
+)
+
+// Ensure provider defined types fully satisfy framework interfaces.
+var _ datasource.DataSource = &ClustersDataSource{}
+var _ datasource.DataSourceWithValidateConfig = &ClustersDataSource{}
+var _ datasource.DataSourceWithConfigValidators = &ClustersDataSource{}
+
+func NewClustersDataSource() datasource.DataSource {
+	return &ClustersDataSource{}
+}
+
+// ClustersDataSource defines the data source implementation.
+type ClustersDataSource struct {
+	client                fulfillmentv1.ClustersClient
+	maxConcurrentRequests int
+}
+
+// ClustersDataSourceModel describes the data source data model.
+type ClustersDataSourceModel struct {
+	IDs         types.List   `tfsdk:"ids"`
+	SkipMissing types.Bool   `tfsdk:"skip_missing"`
+	Filter      types.String `tfsdk:"filter"`
+	NameRegex   types.String `tfsdk:"name_regex"`
+	FailIfEmpty types.Bool   `tfsdk:"fail_if_empty"`
+	Clusters    types.List   `tfsdk:"clusters"`
+}
+
+// ClustersItemModel describes a cluster returned by the data source.
+type ClustersItemModel struct {
+	ID         types.String `tfsdk:"id"`
+	Name       types.String `tfsdk:"name"`
+	Template   types.String `tfsdk:"template"`
+	State      types.String `tfsdk:"state"`
+	ApiURL     types.String `tfsdk:"api_url"`
+	ConsoleURL types.String `tfsdk:"console_url"`
+}
+
+var clustersItemAttrTypes = map[string]attr.Type{
+	"id":          types.StringType,
+	"name":        types.StringType,
+	"template":    types.StringType,
+	"state":       types.StringType,
+	"api_url":     types.StringType,
+	"console_url": types.StringType,
+}
+
+func (d *ClustersDataSource) Metadata(ctx context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_clusters"
+}
+
+func (d *ClustersDataSource) Schema(ctx context.Context, req datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Description: "Fetches information about several existing OSAC clusters at once.",
+		Attributes: map[string]schema.Attribute{
+			"ids": schema.ListAttribute{
+				Description: "Unique identifiers of the clusters to fetch. The clusters are fetched concurrently. " +
+					"When omitted, all the clusters are listed.",
+				Optional:    true,
+				ElementType: types.StringType,
+			},
+			"skip_missing": schema.BoolAttribute{
+				Description: "Omit clusters that don't exist from the result instead of failing.",
+				Optional:    true,
+			},
+			"filter": schema.StringAttribute{
+				Description: "Filter passed to the server when listing the clusters. Can't be used with ids.",
+				Optional:    true,
+			},
+			"name_regex": schema.StringAttribute{
+				Description: "Regular expression that the names of the returned clusters must match.",
+				Optional:    true,
+			},
+			"fail_if_empty": failIfEmptyAttribute("clusters"),
+			"clusters": schema.ListNestedAttribute{
+				Description: "Clusters found, in the same order as the requested identifiers, or in the order " +
+					"returned by the server when listing. Empty when nothing matches.",
+				Computed: true,
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: map[string]schema.Attribute{
+						"id": schema.StringAttribute{
+							Description: "Unique identifier of the cluster.",
+							Computed:    true,
+						},
+						"name": schema.StringAttribute{
+							Description: "Human-friendly name of the cluster.",
+							Computed:    true,
+						},
+						"template": schema.StringAttribute{
+							Description: "Reference to the cluster template ID.",
+							Computed:    true,
+						},
+						"state": schema.StringAttribute{
+							Description: "Current state of the cluster.",
+							Computed:    true,
+						},
+						"api_url": schema.StringAttribute{
+							Description: "URL of the API server of the cluster.",
+							Computed:    true,
+						},
+						"console_url": schema.StringAttribute{
+							Description: "URL of the console of the cluster.",
+							Computed:    true,
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func (d *ClustersDataSource) ConfigValidators(ctx context.Context) []datasource.ConfigValidator {
+	return []datasource.ConfigValidator{
+		datasourcevalidator.Conflicting(path.MatchRoot("ids"), path.MatchRoot("filter")),
+	}
+}
+
+func (d *ClustersDataSource) ValidateConfig(ctx context.Context, req datasource.ValidateConfigRequest, resp *datasource.ValidateConfigResponse) {
+	var nameRegex types.String
+	resp.Diagnostics.Append(req.Config.GetAttribute(ctx, path.Root("name_regex"), &nameRegex)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	if _, err := compileRegex(nameRegex); err != nil {
+		resp.Diagnostics.AddAttributeError(path.Root("name_regex"), "Invalid name regular expression", err.Error())
+	}
+}
+
+func (d *ClustersDataSource) Configure(ctx context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	providerData, ok := req.ProviderData.(*client.ProviderData)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Data Source Configure Type",
+			fmt.Sprintf("Expected *client.ProviderData, got: %T", req.ProviderData),
+		)
+		return
+	}
+
+	d.client = providerData.ClustersClient
+	d.maxConcurrentRequests = providerData.MaxConcurrentRequests
+}
+
+func (d *ClustersDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	var data ClustersDataSourceModel
+
+	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	nameRegex, err := compileRegex(data.NameRegex)
+	if err != nil {
+		resp.Diagnostics.AddAttributeError(path.Root("name_regex"), "Invalid name regular expression", err.Error())
+		return
+	}
+
+	var clusters []*fulfillmentv1.Cluster
+	if data.IDs.IsNull() {
+		clusters, err = d.listClusters(ctx, data.Filter)
+		if err != nil {
+			resp.Diagnostics.AddError("Failed to list clusters", err.Error())
+			return
+		}
+	} else {
+		clusters = d.getClusters(ctx, &data, &resp.Diagnostics)
+		if resp.Diagnostics.HasError() {
+			return
+		}
+	}
+
+	items := make([]ClustersItemModel, 0, len(clusters))
+	for _, cluster := range clusters {
+		if nameRegex != nil && (cluster.Metadata == nil || !nameRegex.MatchString(cluster.Metadata.Name)) {
+			continue
+		}
+		items = append(items, clustersItemFromCluster(cluster))
+	}
+
+	checkNotEmpty(data.FailIfEmpty, "clusters", len(items), &resp.Diagnostics)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	clustersValue, diags := types.ListValueFrom(ctx, types.ObjectType{AttrTypes: clustersItemAttrTypes}, items)
+	resp.Diagnostics.Append(diags...)
+	data.Clusters = clustersValue
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+// listClusters fetches all the clusters that match the filter, one page at a time.
+func (d *ClustersDataSource) listClusters(ctx context.Context, filter types.String) ([]*fulfillmentv1.Cluster, error) {
+	return listAll(func(offset, limit int32) ([]*fulfillmentv1.Cluster, int32, error) {
+		listReq := &fulfillmentv1.ClustersListRequest{
+			Offset: &offset,
+			Limit:  &limit,
+		}
+		if !filter.IsNull() {
+			listReq.Filter = filter.ValueStringPointer()
+		}
+		listResp, err := d.client.List(ctx, listReq)
+		if err != nil {
+			return nil, 0, err
+		}
+		return listResp.Items, listResp.GetTotal(), nil
+	})
+}
+
+// getClusters fetches the clusters with the identifiers of the configuration, in the same order. Missing clusters
+// are omitted when skip_missing is set, and reported as errors otherwise.
+func (d *ClustersDataSource) getClusters(ctx context.Context, data *ClustersDataSourceModel, diagnostics *diag.Diagnostics) []*fulfillmentv1.Cluster {
+	var ids []string
+	diagnostics.Append(data.IDs.ElementsAs(ctx, &ids, false)...)
+	if diagnostics.HasError() {
+		return nil
+	}
+
+	// Fetch the clusters concurrently, keeping the results in the order of the identifiers
+	limit := d.maxConcurrentRequests
+	if limit <= 0 {
+		limit = client.DefaultMaxConcurrentRequests
+	}
+	clusters := make([]*fulfillmentv1.Cluster, len(ids))
+	errs := make([]error, len(ids))
+	semaphore := make(chan struct{}, limit)
+	var wg sync.WaitGroup
+	for i, id := range ids {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			semaphore <- struct{}{}
+			defer func() { <-semaphore }()
+
+			getResp, err := d.client.Get(ctx, &fulfillmentv1.ClustersGetRequest{Id: id})
+			if err != nil {
+				errs[i] = err
+				return
+			}
+			clusters[i] = getResp.Object
+		}()
+	}
+	wg.Wait()
+
+	found := make([]*fulfillmentv1.Cluster, 0, len(ids))
+	for i, id := range ids {
+		if errs[i] != nil {
+			if status.Code(errs[i]) == codes.NotFound && data.SkipMissing.ValueBool() {
+				continue
+			}
+			diagnostics.AddError("Failed to read cluster", fmt.Sprintf("Cluster %s: %s", id, errs[i].Error()))
+			continue
+		}
+		found = append(found, clusters[i])
+	}
+	return found
+}
+
+func clustersItemFromCluster(cluster *fulfillmentv1.Cluster) ClustersItemModel {
+	item := ClustersItemModel{
+		ID:         types.StringValue(cluster.Id),
+		Name:       types.StringNull(),
+		Template:   types.StringNull(),
+		State:      types.StringNull(),
+		ApiURL:     types.StringNull(),
+		ConsoleURL: types.StringNull(),
+	}
+
+	if cluster.Metadata != nil {
+		item.Name = types.StringValue(cluster.Metadata.Name)
+	}
+
+	if cluster.Spec != nil {
+		item.Template = types.StringValue(cluster.Spec.Template)
+	}
+
+	if cluster.Status != nil {
+		item.State = types.StringValue(cluster.Status.State.String())
+		item.ApiURL = types.StringValue(cluster.Status.ApiUrl)
+		item.ConsoleURL = types.StringValue(cluster.Status.ConsoleUrl)
+	}
+
+	return item
+}
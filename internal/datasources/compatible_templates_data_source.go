@@ -0,0 +1,192 @@
+/*
+Copyright (c) 2025 Red Hat Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License"); you may not use this file except in compliance with the
+License. You may obtain a copy of the License at
+
+  http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software distributed under the License is distributed on an
+"AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the License for the specific
+language governing permissions and limitations under the License.
+*/
+
+package datasources
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+
+	fulfillmentv1 "github.com/innabox/fulfillment-common/api/fulfillment/v1"
+
+	"github.com/innabox/terraform-provider-osac/internal/client"
+)
+
+// Ensure provider defined types fully satisfy framework interfaces.
+var _ datasource.DataSource = &CompatibleTemplatesDataSource{}
+
+func NewCompatibleTemplatesDataSource() datasource.DataSource {
+	return &CompatibleTemplatesDataSource{}
+}
+
+// CompatibleTemplatesDataSource defines the data source implementation.
+type CompatibleTemplatesDataSource struct {
+	client fulfillmentv1.ClusterTemplatesClient
+}
+
+// CompatibleTemplatesDataSourceModel describes the data source data model.
+type CompatibleTemplatesDataSourceModel struct {
+	HostClass   types.String `tfsdk:"host_class"`
+	FailIfEmpty types.Bool   `tfsdk:"fail_if_empty"`
+	Templates   types.List   `tfsdk:"templates"`
+}
+
+// CompatibleTemplateModel describes a template returned by the data source.
+type CompatibleTemplateModel struct {
+	ID          types.String `tfsdk:"id"`
+	Title       types.String `tfsdk:"title"`
+	Description types.String `tfsdk:"description"`
+	NodeSets    types.List   `tfsdk:"node_sets"`
+}
+
+var compatibleTemplateAttrTypes = map[string]attr.Type{
+	"id":          types.StringType,
+	"title":       types.StringType,
+	"description": types.StringType,
+	"node_sets":   types.ListType{ElemType: types.StringType},
+}
+
+func (d *CompatibleTemplatesDataSource) Metadata(ctx context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_compatible_templates"
+}
+
+func (d *CompatibleTemplatesDataSource) Schema(ctx context.Context, req datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Description: "Lists the cluster templates that can run on a host class. The API doesn't expose compatibility " +
+			"information, so this is a best-effort match: a template is considered compatible when at least one of " +
+			"its default node sets uses the host class.",
+		Attributes: map[string]schema.Attribute{
+			"host_class": schema.StringAttribute{
+				Description: "Host class ID to find compatible templates for.",
+				Required:    true,
+			},
+			"fail_if_empty": failIfEmptyAttribute("templates"),
+			"templates": schema.ListNestedAttribute{
+				Description: "Compatible cluster templates, sorted by ID.",
+				Computed:    true,
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: map[string]schema.Attribute{
+						"id": schema.StringAttribute{
+							Description: "Unique identifier of the cluster template.",
+							Computed:    true,
+						},
+						"title": schema.StringAttribute{
+							Description: "Human-friendly short description of the template.",
+							Computed:    true,
+						},
+						"description": schema.StringAttribute{
+							Description: "Human-friendly long description of the template in Markdown format.",
+							Computed:    true,
+						},
+						"node_sets": schema.ListAttribute{
+							Description: "Names of the default node sets of the template that use the host class.",
+							Computed:    true,
+							ElementType: types.StringType,
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func (d *CompatibleTemplatesDataSource) Configure(ctx context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	providerData, ok := req.ProviderData.(*client.ProviderData)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Data Source Configure Type",
+			fmt.Sprintf("Expected *client.ProviderData, got: %T", req.ProviderData),
+		)
+		return
+	}
+
+	d.client = providerData.ClusterTemplatesClient
+}
+
+func (d *CompatibleTemplatesDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	var data CompatibleTemplatesDataSourceModel
+
+	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	templates, err := d.listTemplates(ctx)
+	if err != nil {
+		resp.Diagnostics.AddError("Failed to list cluster templates", err.Error())
+		return
+	}
+
+	hostClass := data.HostClass.ValueString()
+	items := make([]CompatibleTemplateModel, 0)
+	for _, template := range templates {
+		var nodeSets []string
+		for name, nodeSet := range template.NodeSets {
+			if nodeSet.HostClass == hostClass {
+				nodeSets = append(nodeSets, name)
+			}
+		}
+		if len(nodeSets) == 0 {
+			continue
+		}
+		slices.Sort(nodeSets)
+
+		nodeSetsValue, diags := types.ListValueFrom(ctx, types.StringType, nodeSets)
+		resp.Diagnostics.Append(diags...)
+		items = append(items, CompatibleTemplateModel{
+			ID:          types.StringValue(template.Id),
+			Title:       types.StringValue(template.Title),
+			Description: types.StringValue(template.Description),
+			NodeSets:    nodeSetsValue,
+		})
+	}
+	slices.SortFunc(items, func(a, b CompatibleTemplateModel) int {
+		return strings.Compare(a.ID.ValueString(), b.ID.ValueString())
+	})
+
+	checkNotEmpty(data.FailIfEmpty, "templates", len(items), &resp.Diagnostics)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	templatesValue, diags := types.ListValueFrom(ctx, types.ObjectType{AttrTypes: compatibleTemplateAttrTypes}, items)
+	resp.Diagnostics.Append(diags...)
+	data.Templates = templatesValue
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+// listTemplates fetches all the cluster templates, one page at a time.
+func (d *CompatibleTemplatesDataSource) listTemplates(ctx context.Context) ([]*fulfillmentv1.ClusterTemplate, error) {
+	return listAll(func(offset, limit int32) ([]*fulfillmentv1.ClusterTemplate, int32, error) {
+		listResp, err := d.client.List(ctx, &fulfillmentv1.ClusterTemplatesListRequest{
+			Offset: &offset,
+			Limit:  &limit,
+		})
+		if err != nil {
+			return nil, 0, err
+		}
+		return listResp.Items, listResp.GetTotal(), nil
+	})
+}
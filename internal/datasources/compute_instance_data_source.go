@@ -17,17 +17,21 @@ import (
 	"context"
 	"fmt"
 
+	"github.com/hashicorp/terraform-plugin-framework-timeouts/datasource/timeouts"
 	"github.com/hashicorp/terraform-plugin-framework/datasource"
 	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/path"
 	"github.com/hashicorp/terraform-plugin-framework/types"
 
 	fulfillmentv1 "github.com/innabox/fulfillment-common/api/fulfillment/v1"
 
 	"github.com/innabox/terraform-provider-osac/internal/client"
+	"github.com/innabox/terraform-provider-osac/internal/waiter"
 )
 
 // Ensure provider defined types fully satisfy framework interfaces.
 var _ datasource.DataSource = &ComputeInstanceDataSource{}
+var _ datasource.DataSourceWithValidateConfig = &ComputeInstanceDataSource{}
 
 func NewComputeInstanceDataSource() datasource.DataSource {
 	return &ComputeInstanceDataSource{}
@@ -36,15 +40,20 @@ func NewComputeInstanceDataSource() datasource.DataSource {
 // ComputeInstanceDataSource defines the data source implementation.
 type ComputeInstanceDataSource struct {
 	client fulfillmentv1.ComputeInstancesClient
+	wait   client.WaitSettings
 }
 
 // ComputeInstanceDataSourceModel describes the data source data model.
 type ComputeInstanceDataSourceModel struct {
-	ID        types.String `tfsdk:"id"`
-	Name      types.String `tfsdk:"name"`
-	Template  types.String `tfsdk:"template"`
-	State     types.String `tfsdk:"state"`
-	IPAddress types.String `tfsdk:"ip_address"`
+	ID           types.String   `tfsdk:"id"`
+	Name         types.String   `tfsdk:"name"`
+	CreatedAt    types.String   `tfsdk:"created_at"`
+	Template     types.String   `tfsdk:"template"`
+	State        types.String   `tfsdk:"state"`
+	IPAddress    types.String   `tfsdk:"ip_address"`
+	WaitForReady types.Bool     `tfsdk:"wait_for_ready"`
+	PollInterval types.String   `tfsdk:"poll_interval"`
+	Timeouts     timeouts.Value `tfsdk:"timeouts"`
 }
 
 func (d *ComputeInstanceDataSource) Metadata(ctx context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
@@ -63,6 +72,10 @@ func (d *ComputeInstanceDataSource) Schema(ctx context.Context, req datasource.S
 				Description: "Human-friendly name of the compute instance.",
 				Computed:    true,
 			},
+			"created_at": schema.StringAttribute{
+				Description: "Time when the compute instance was created, in RFC 3339 format.",
+				Computed:    true,
+			},
 			"template": schema.StringAttribute{
 				Description: "Reference to the compute instance template ID.",
 				Computed:    true,
@@ -75,10 +88,33 @@ func (d *ComputeInstanceDataSource) Schema(ctx context.Context, req datasource.S
 				Description: "IP address of the compute instance.",
 				Computed:    true,
 			},
+			"wait_for_ready": schema.BoolAttribute{
+				Description: "Wait for the compute instance to be ready before reading it. Defaults to false.",
+				Optional:    true,
+			},
+			"poll_interval": schema.StringAttribute{
+				Description: "Time between checks of the compute instance state while waiting for it to be ready, like '30s'. " +
+					"Must be at least 1s and less than 3m. When not set, the state is checked every 5 to 10 seconds.",
+				Optional: true,
+			},
+		},
+		Blocks: map[string]schema.Block{
+			"timeouts": timeouts.Block(ctx),
 		},
 	}
 }
 
+func (d *ComputeInstanceDataSource) ValidateConfig(ctx context.Context, req datasource.ValidateConfigRequest, resp *datasource.ValidateConfigResponse) {
+	var pollInterval types.String
+	resp.Diagnostics.Append(req.Config.GetAttribute(ctx, path.Root("poll_interval"), &pollInterval)...)
+	if resp.Diagnostics.HasError() || pollInterval.IsNull() || pollInterval.IsUnknown() {
+		return
+	}
+	if _, err := waiter.ParsePollInterval(pollInterval.ValueString()); err != nil {
+		resp.Diagnostics.AddAttributeError(path.Root("poll_interval"), "Invalid poll interval", err.Error())
+	}
+}
+
 func (d *ComputeInstanceDataSource) Configure(ctx context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
 	if req.ProviderData == nil {
 		return
@@ -94,6 +130,7 @@ func (d *ComputeInstanceDataSource) Configure(ctx context.Context, req datasourc
 	}
 
 	d.client = providerData.ComputeInstancesClient
+	d.wait = providerData.Wait
 }
 
 func (d *ComputeInstanceDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
@@ -104,20 +141,69 @@ func (d *ComputeInstanceDataSource) Read(ctx context.Context, req datasource.Rea
 		return
 	}
 
-	getResp, err := d.client.Get(ctx, &fulfillmentv1.ComputeInstancesGetRequest{
-		Id: data.ID.ValueString(),
-	})
-	if err != nil {
-		resp.Diagnostics.AddError("Failed to read compute instance", err.Error())
-		return
+	var instance *fulfillmentv1.ComputeInstance
+	if data.WaitForReady.ValueBool() {
+		readTimeout, diags := data.Timeouts.Read(ctx, waiter.DefaultReadTimeout)
+		resp.Diagnostics.Append(diags...)
+		if resp.Diagnostics.HasError() {
+			return
+		}
+
+		pollInterval := d.wait.PollInterval
+		if !data.PollInterval.IsNull() {
+			var err error
+			pollInterval, err = waiter.ParsePollInterval(data.PollInterval.ValueString())
+			if err != nil {
+				resp.Diagnostics.AddAttributeError(path.Root("poll_interval"), "Invalid poll interval", err.Error())
+				return
+			}
+		}
+
+		// Cancel requests in flight, not only the polling, when the read timeout expires
+		ctx, cancel := context.WithTimeout(ctx, readTimeout)
+		defer cancel()
+
+		result, err := waiter.WaitForReady(ctx, waiter.Config{
+			PendingStates: []string{
+				fulfillmentv1.ComputeInstanceState_COMPUTE_INSTANCE_STATE_UNSPECIFIED.String(),
+				fulfillmentv1.ComputeInstanceState_COMPUTE_INSTANCE_STATE_PROGRESSING.String(),
+			},
+			TargetStates: []string{
+				fulfillmentv1.ComputeInstanceState_COMPUTE_INSTANCE_STATE_READY.String(),
+			},
+			RefreshFunc:       d.instanceStateRefreshFunc(ctx, data.ID.ValueString()),
+			Timeout:           readTimeout,
+			FixedPollInterval: pollInterval,
+			MinPollInterval:   d.wait.MinPollInterval,
+			BackoffMultiplier: d.wait.BackoffMultiplier,
+			MaxPollInterval:   d.wait.MaxPollInterval,
+			SlowWarningAfter:  d.wait.SlowWarningAfter,
+		})
+		if err != nil {
+			resp.Diagnostics.AddError(
+				"Error waiting for compute instance to be ready",
+				fmt.Sprintf("Compute instance %s: %s", data.ID.ValueString(), err.Error()),
+			)
+			return
+		}
+		instance = result.(*fulfillmentv1.ComputeInstance)
+	} else {
+		getResp, err := d.client.Get(ctx, &fulfillmentv1.ComputeInstancesGetRequest{
+			Id: data.ID.ValueString(),
+		})
+		if err != nil {
+			resp.Diagnostics.AddError("Failed to read compute instance", err.Error())
+			return
+		}
+		instance = getResp.Object
 	}
 
-	instance := getResp.Object
 	data.ID = types.StringValue(instance.Id)
 
 	if instance.Metadata != nil {
 		data.Name = types.StringValue(instance.Metadata.Name)
 	}
+	data.CreatedAt = client.CreatedAtValue(instance.Metadata)
 
 	if instance.Spec != nil {
 		data.Template = types.StringValue(instance.Spec.Template)
@@ -130,3 +216,25 @@ func (d *ComputeInstanceDataSource) Read(ctx context.Context, req datasource.Rea
 
 	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
 }
+
+// instanceStateRefreshFunc returns a StateRefreshFunc that fetches the instance and returns its state.
+func (d *ComputeInstanceDataSource) instanceStateRefreshFunc(ctx context.Context, instanceID string) waiter.StateRefreshFunc {
+	return waiter.LogTransitions(ctx, "compute instance", instanceID, func() (interface{}, string, error) {
+		getResp, err := d.client.Get(ctx, &fulfillmentv1.ComputeInstancesGetRequest{Id: instanceID})
+		if err != nil {
+			return nil, "", fmt.Errorf("failed to get compute instance: %w", err)
+		}
+
+		instance := getResp.Object
+		if instance.Status == nil {
+			return instance, fulfillmentv1.ComputeInstanceState_COMPUTE_INSTANCE_STATE_UNSPECIFIED.String(), nil
+		}
+
+		state := instance.Status.State
+		if state == fulfillmentv1.ComputeInstanceState_COMPUTE_INSTANCE_STATE_FAILED {
+			return nil, state.String(), fmt.Errorf("compute instance reached FAILED state%s", client.FailureReason(instance.Status.Conditions))
+		}
+
+		return instance, state.String(), nil
+	})
+}
@@ -14,12 +14,15 @@ language governing permissions and limitations under the License.
 package datasources
 
 import (
+	"bytes"
 	"context"
+	"encoding/json"
 	"fmt"
 
 	"github.com/hashicorp/terraform-plugin-framework/datasource"
 	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
 	"github.com/hashicorp/terraform-plugin-framework/types"
+	"google.golang.org/protobuf/encoding/protojson"
 
 	fulfillmentv1 "github.com/innabox/fulfillment-common/api/fulfillment/v1"
 
@@ -40,9 +43,11 @@ type ComputeInstanceTemplateDataSource struct {
 
 // ComputeInstanceTemplateDataSourceModel describes the data source data model.
 type ComputeInstanceTemplateDataSourceModel struct {
-	ID          types.String `tfsdk:"id"`
-	Title       types.String `tfsdk:"title"`
-	Description types.String `tfsdk:"description"`
+	ID             types.String `tfsdk:"id"`
+	Title          types.String `tfsdk:"title"`
+	Description    types.String `tfsdk:"description"`
+	Parameters     types.List   `tfsdk:"parameters"`
+	DefinitionJSON types.String `tfsdk:"definition_json"`
 }
 
 func (d *ComputeInstanceTemplateDataSource) Metadata(ctx context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
@@ -65,6 +70,12 @@ func (d *ComputeInstanceTemplateDataSource) Schema(ctx context.Context, req data
 				Description: "Human-friendly long description of the template in Markdown format.",
 				Computed:    true,
 			},
+			"parameters": templateParametersAttribute(),
+			"definition_json": schema.StringAttribute{
+				Description: "Full definition of the compute instance template as returned by the API, serialized as JSON. Gives " +
+					"access to the fields that aren't mapped to attributes. Use jsondecode to read it.",
+				Computed: true,
+			},
 		},
 	}
 }
@@ -107,5 +118,22 @@ func (d *ComputeInstanceTemplateDataSource) Read(ctx context.Context, req dataso
 	data.Title = types.StringValue(template.Title)
 	data.Description = types.StringValue(template.Description)
 
+	parametersValue, diags := types.ListValueFrom(ctx, types.ObjectType{AttrTypes: templateParameterAttrTypes},
+		templateParameterModels(template.Parameters))
+	resp.Diagnostics.Append(diags...)
+	data.Parameters = parametersValue
+
+	// The output of protojson deliberately varies in whitespace, so it is compacted to keep the value stable
+	definition, err := protojson.Marshal(template)
+	var compacted bytes.Buffer
+	if err == nil {
+		err = json.Compact(&compacted, definition)
+	}
+	if err != nil {
+		resp.Diagnostics.AddError("Failed to serialize compute instance template", err.Error())
+		return
+	}
+	data.DefinitionJSON = types.StringValue(compacted.String())
+
 	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
 }
@@ -0,0 +1,117 @@
+/*
+Copyright (c) 2025 Red Hat Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License"); you may not use this file except in compliance with the
+License. You may obtain a copy of the License at
+
+  http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software distributed under the License is distributed on an
+"AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the License for the specific
+language governing permissions and limitations under the License.
+*/
+
+package datasources
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	fulfillmentv1 "github.com/innabox/fulfillment-common/api/fulfillment/v1"
+
+	"github.com/innabox/terraform-provider-osac/internal/client"
+)
+
+// Ensure provider defined types fully satisfy framework interfaces.
+var _ datasource.DataSource = &ConnectivityDataSource{}
+
+func NewConnectivityDataSource() datasource.DataSource {
+	return &ConnectivityDataSource{}
+}
+
+// ConnectivityDataSource defines the data source implementation.
+type ConnectivityDataSource struct {
+	client fulfillmentv1.ClusterTemplatesClient
+}
+
+// ConnectivityDataSourceModel describes the data source data model.
+type ConnectivityDataSourceModel struct {
+	Reachable types.Bool   `tfsdk:"reachable"`
+	Error     types.String `tfsdk:"error"`
+}
+
+func (d *ConnectivityDataSource) Metadata(ctx context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_connectivity"
+}
+
+func (d *ConnectivityDataSource) Schema(ctx context.Context, req datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Description: "Checks that the OSAC API can be reached with the configured endpoint and credentials, with a " +
+			"cheap call. Use it with a postcondition to fail early with a clear message.",
+		Attributes: map[string]schema.Attribute{
+			"reachable": schema.BoolAttribute{
+				Description: "Whether the API answered the call successfully.",
+				Computed:    true,
+			},
+			"error": schema.StringAttribute{
+				Description: "Why the API couldn't be reached, or null if it could.",
+				Computed:    true,
+			},
+		},
+	}
+}
+
+func (d *ConnectivityDataSource) Configure(ctx context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	providerData, ok := req.ProviderData.(*client.ProviderData)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Data Source Configure Type",
+			fmt.Sprintf("Expected *client.ProviderData, got: %T", req.ProviderData),
+		)
+		return
+	}
+
+	d.client = providerData.ClusterTemplatesClient
+}
+
+func (d *ConnectivityDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	var data ConnectivityDataSourceModel
+
+	limit := int32(1)
+	_, err := d.client.List(ctx, &fulfillmentv1.ClusterTemplatesListRequest{
+		Limit: &limit,
+	})
+	if err != nil {
+		data.Reachable = types.BoolValue(false)
+		data.Error = types.StringValue(connectivityError(err))
+	} else {
+		data.Reachable = types.BoolValue(true)
+		data.Error = types.StringNull()
+	}
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+// connectivityError describes the error of the connectivity check, pointing to the setting that is most likely wrong.
+func connectivityError(err error) string {
+	switch status.Code(err) {
+	case codes.Unavailable, codes.DeadlineExceeded:
+		return fmt.Sprintf("the endpoint can't be reached, check the endpoint and the TLS settings: %s", err)
+	case codes.Unauthenticated:
+		return fmt.Sprintf("the credentials were rejected, check the token or the OAuth2 settings: %s", err)
+	case codes.PermissionDenied:
+		return fmt.Sprintf("the credentials aren't allowed to list templates: %s", err)
+	default:
+		return err.Error()
+	}
+}
@@ -0,0 +1,180 @@
+/*
+Copyright (c) 2025 Red Hat Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License"); you may not use this file except in compliance with the
+License. You may obtain a copy of the License at
+
+  http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software distributed under the License is distributed on an
+"AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the License for the specific
+language governing permissions and limitations under the License.
+*/
+
+package datasources
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+
+	fulfillmentv1 "github.com/innabox/fulfillment-common/api/fulfillment/v1"
+
+	"github.com/innabox/terraform-provider-osac/internal/client"
+)
+
+// Ensure provider defined types fully satisfy framework interfaces.
+var _ datasource.DataSource = &HostClassesDataSource{}
+var _ datasource.DataSourceWithValidateConfig = &HostClassesDataSource{}
+
+func NewHostClassesDataSource() datasource.DataSource {
+	return &HostClassesDataSource{}
+}
+
+// HostClassesDataSource defines the data source implementation.
+type HostClassesDataSource struct {
+	client fulfillmentv1.HostClassesClient
+}
+
+// HostClassesDataSourceModel describes the data source data model.
+type HostClassesDataSourceModel struct {
+	TitleRegex  types.String `tfsdk:"title_regex"`
+	FailIfEmpty types.Bool   `tfsdk:"fail_if_empty"`
+	HostClasses types.List   `tfsdk:"host_classes"`
+}
+
+// HostClassesItemModel describes a host class returned by the data source.
+type HostClassesItemModel struct {
+	ID          types.String `tfsdk:"id"`
+	Title       types.String `tfsdk:"title"`
+	Description types.String `tfsdk:"description"`
+}
+
+var hostClassesItemAttrTypes = map[string]attr.Type{
+	"id":          types.StringType,
+	"title":       types.StringType,
+	"description": types.StringType,
+}
+
+func (d *HostClassesDataSource) Metadata(ctx context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_host_classes"
+}
+
+func (d *HostClassesDataSource) Schema(ctx context.Context, req datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Description: "Fetches information about all the available OSAC host classes.",
+		Attributes: map[string]schema.Attribute{
+			"title_regex": schema.StringAttribute{
+				Description: "Regular expression that the titles of the returned host classes must match.",
+				Optional:    true,
+			},
+			"fail_if_empty": failIfEmptyAttribute("host classes"),
+			"host_classes": schema.ListNestedAttribute{
+				Description: "Host classes found, in the order returned by the server. Empty when nothing matches.",
+				Computed:    true,
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: map[string]schema.Attribute{
+						"id": schema.StringAttribute{
+							Description: "Unique identifier of the host class.",
+							Computed:    true,
+						},
+						"title": schema.StringAttribute{
+							Description: "Title of the host class.",
+							Computed:    true,
+						},
+						"description": schema.StringAttribute{
+							Description: "Description of the host class.",
+							Computed:    true,
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func (d *HostClassesDataSource) ValidateConfig(ctx context.Context, req datasource.ValidateConfigRequest, resp *datasource.ValidateConfigResponse) {
+	var titleRegex types.String
+	resp.Diagnostics.Append(req.Config.GetAttribute(ctx, path.Root("title_regex"), &titleRegex)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	if _, err := compileRegex(titleRegex); err != nil {
+		resp.Diagnostics.AddAttributeError(path.Root("title_regex"), "Invalid title regular expression", err.Error())
+	}
+}
+
+func (d *HostClassesDataSource) Configure(ctx context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	providerData, ok := req.ProviderData.(*client.ProviderData)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Data Source Configure Type",
+			fmt.Sprintf("Expected *client.ProviderData, got: %T", req.ProviderData),
+		)
+		return
+	}
+
+	d.client = providerData.HostClassesClient
+}
+
+func (d *HostClassesDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	var data HostClassesDataSourceModel
+
+	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	titleRegex, err := compileRegex(data.TitleRegex)
+	if err != nil {
+		resp.Diagnostics.AddAttributeError(path.Root("title_regex"), "Invalid title regular expression", err.Error())
+		return
+	}
+
+	hostClasses, err := listAll(func(offset, limit int32) ([]*fulfillmentv1.HostClass, int32, error) {
+		listResp, err := d.client.List(ctx, &fulfillmentv1.HostClassesListRequest{
+			Offset: &offset,
+			Limit:  &limit,
+		})
+		if err != nil {
+			return nil, 0, err
+		}
+		return listResp.Items, listResp.GetTotal(), nil
+	})
+	if err != nil {
+		resp.Diagnostics.AddError("Failed to list host classes", err.Error())
+		return
+	}
+
+	items := make([]HostClassesItemModel, 0, len(hostClasses))
+	for _, hostClass := range hostClasses {
+		if titleRegex != nil && !titleRegex.MatchString(hostClass.Title) {
+			continue
+		}
+		items = append(items, HostClassesItemModel{
+			ID:          types.StringValue(hostClass.Id),
+			Title:       types.StringValue(hostClass.Title),
+			Description: types.StringValue(hostClass.Description),
+		})
+	}
+
+	checkNotEmpty(data.FailIfEmpty, "host classes", len(items), &resp.Diagnostics)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	hostClassesValue, diags := types.ListValueFrom(ctx, types.ObjectType{AttrTypes: hostClassesItemAttrTypes}, items)
+	resp.Diagnostics.Append(diags...)
+	data.HostClasses = hostClassesValue
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
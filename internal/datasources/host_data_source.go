@@ -17,8 +17,10 @@ import (
 	"context"
 	"fmt"
 
+	"github.com/hashicorp/terraform-plugin-framework-validators/datasourcevalidator"
 	"github.com/hashicorp/terraform-plugin-framework/datasource"
 	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/path"
 	"github.com/hashicorp/terraform-plugin-framework/types"
 
 	fulfillmentv1 "github.com/innabox/fulfillment-common/api/fulfillment/v1"
@@ -28,6 +30,7 @@ import (
 
 // Ensure provider defined types fully satisfy framework interfaces.
 var _ datasource.DataSource = &HostDataSource{}
+var _ datasource.DataSourceWithConfigValidators = &HostDataSource{}
 
 func NewHostDataSource() datasource.DataSource {
 	return &HostDataSource{}
@@ -42,6 +45,7 @@ type HostDataSource struct {
 type HostDataSourceModel struct {
 	ID         types.String `tfsdk:"id"`
 	Name       types.String `tfsdk:"name"`
+	CreatedAt  types.String `tfsdk:"created_at"`
 	PowerState types.String `tfsdk:"power_state"`
 	State      types.String `tfsdk:"state"`
 }
@@ -55,11 +59,18 @@ func (d *HostDataSource) Schema(ctx context.Context, req datasource.SchemaReques
 		Description: "Fetches information about an existing OSAC host.",
 		Attributes: map[string]schema.Attribute{
 			"id": schema.StringAttribute{
-				Description: "Unique identifier of the host.",
-				Required:    true,
+				Description: "Unique identifier of the host. Exactly one of id or name must be set.",
+				Optional:    true,
+				Computed:    true,
 			},
 			"name": schema.StringAttribute{
-				Description: "Human-friendly name of the host.",
+				Description: "Human-friendly name of the host. When id isn't set, the host is looked up by " +
+					"name, which must match exactly one host.",
+				Optional: true,
+				Computed: true,
+			},
+			"created_at": schema.StringAttribute{
+				Description: "Time when the host was created, in RFC 3339 format.",
 				Computed:    true,
 			},
 			"power_state": schema.StringAttribute{
@@ -74,6 +85,12 @@ func (d *HostDataSource) Schema(ctx context.Context, req datasource.SchemaReques
 	}
 }
 
+func (d *HostDataSource) ConfigValidators(ctx context.Context) []datasource.ConfigValidator {
+	return []datasource.ConfigValidator{
+		datasourcevalidator.ExactlyOneOf(path.MatchRoot("id"), path.MatchRoot("name")),
+	}
+}
+
 func (d *HostDataSource) Configure(ctx context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
 	if req.ProviderData == nil {
 		return
@@ -99,6 +116,27 @@ func (d *HostDataSource) Read(ctx context.Context, req datasource.ReadRequest, r
 		return
 	}
 
+	// Resolve the identifier from the name if needed
+	if data.ID.IsNull() {
+		found, err := findByName("host", data.Name.ValueString(),
+			func(filter string, offset, limit int32) ([]*fulfillmentv1.Host, int32, error) {
+				listResp, err := d.client.List(ctx, &fulfillmentv1.HostsListRequest{
+					Filter: &filter,
+					Offset: &offset,
+					Limit:  &limit,
+				})
+				if err != nil {
+					return nil, 0, err
+				}
+				return listResp.Items, listResp.GetTotal(), nil
+			})
+		if err != nil {
+			resp.Diagnostics.AddAttributeError(path.Root("name"), "Failed to find host", err.Error())
+			return
+		}
+		data.ID = types.StringValue(found.Id)
+	}
+
 	getResp, err := d.client.Get(ctx, &fulfillmentv1.HostsGetRequest{
 		Id: data.ID.ValueString(),
 	})
@@ -113,6 +151,7 @@ func (d *HostDataSource) Read(ctx context.Context, req datasource.ReadRequest, r
 	if host.Metadata != nil {
 		data.Name = types.StringValue(host.Metadata.Name)
 	}
+	data.CreatedAt = client.CreatedAtValue(host.Metadata)
 
 	if host.Status != nil {
 		data.State = types.StringValue(host.Status.State.String())
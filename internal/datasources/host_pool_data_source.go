@@ -17,8 +17,10 @@ import (
 	"context"
 	"fmt"
 
+	"github.com/hashicorp/terraform-plugin-framework-validators/datasourcevalidator"
 	"github.com/hashicorp/terraform-plugin-framework/datasource"
 	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/path"
 	"github.com/hashicorp/terraform-plugin-framework/types"
 
 	fulfillmentv1 "github.com/innabox/fulfillment-common/api/fulfillment/v1"
@@ -28,6 +30,7 @@ import (
 
 // Ensure provider defined types fully satisfy framework interfaces.
 var _ datasource.DataSource = &HostPoolDataSource{}
+var _ datasource.DataSourceWithConfigValidators = &HostPoolDataSource{}
 
 func NewHostPoolDataSource() datasource.DataSource {
 	return &HostPoolDataSource{}
@@ -35,15 +38,20 @@ func NewHostPoolDataSource() datasource.DataSource {
 
 // HostPoolDataSource defines the data source implementation.
 type HostPoolDataSource struct {
-	client fulfillmentv1.HostPoolsClient
+	client      fulfillmentv1.HostPoolsClient
+	hostsClient fulfillmentv1.HostsClient
 }
 
 // HostPoolDataSourceModel describes the data source data model.
 type HostPoolDataSourceModel struct {
-	ID    types.String `tfsdk:"id"`
-	Name  types.String `tfsdk:"name"`
-	State types.String `tfsdk:"state"`
-	Hosts types.List   `tfsdk:"hosts"`
+	ID             types.String `tfsdk:"id"`
+	Name           types.String `tfsdk:"name"`
+	CreatedAt      types.String `tfsdk:"created_at"`
+	State          types.String `tfsdk:"state"`
+	Hosts          types.List   `tfsdk:"hosts"`
+	ReadHostStates types.Bool   `tfsdk:"read_host_states"`
+	ReadyHosts     types.List   `tfsdk:"ready_hosts"`
+	FailedHosts    types.List   `tfsdk:"failed_hosts"`
 }
 
 func (d *HostPoolDataSource) Metadata(ctx context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
@@ -55,11 +63,18 @@ func (d *HostPoolDataSource) Schema(ctx context.Context, req datasource.SchemaRe
 		Description: "Fetches information about an existing OSAC host pool.",
 		Attributes: map[string]schema.Attribute{
 			"id": schema.StringAttribute{
-				Description: "Unique identifier of the host pool.",
-				Required:    true,
+				Description: "Unique identifier of the host pool. Exactly one of id or name must be set.",
+				Optional:    true,
+				Computed:    true,
 			},
 			"name": schema.StringAttribute{
-				Description: "Human-friendly name of the host pool.",
+				Description: "Human-friendly name of the host pool. When id isn't set, the host pool is looked up by " +
+					"name, which must match exactly one host pool.",
+				Optional: true,
+				Computed: true,
+			},
+			"created_at": schema.StringAttribute{
+				Description: "Time when the host pool was created, in RFC 3339 format.",
 				Computed:    true,
 			},
 			"state": schema.StringAttribute{
@@ -71,10 +86,31 @@ func (d *HostPoolDataSource) Schema(ctx context.Context, req datasource.SchemaRe
 				Computed:    true,
 				ElementType: types.StringType,
 			},
+			"read_host_states": schema.BoolAttribute{
+				Description: "Read the state of each host of the pool to populate ready_hosts and failed_hosts. " +
+					"This issues one additional call per host, so it is disabled by default.",
+				Optional: true,
+			},
+			"ready_hosts": schema.ListAttribute{
+				Description: "IDs of the hosts of the pool that are READY. Only populated when read_host_states is true.",
+				Computed:    true,
+				ElementType: types.StringType,
+			},
+			"failed_hosts": schema.ListAttribute{
+				Description: "IDs of the hosts of the pool that are FAILED. Only populated when read_host_states is true.",
+				Computed:    true,
+				ElementType: types.StringType,
+			},
 		},
 	}
 }
 
+func (d *HostPoolDataSource) ConfigValidators(ctx context.Context) []datasource.ConfigValidator {
+	return []datasource.ConfigValidator{
+		datasourcevalidator.ExactlyOneOf(path.MatchRoot("id"), path.MatchRoot("name")),
+	}
+}
+
 func (d *HostPoolDataSource) Configure(ctx context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
 	if req.ProviderData == nil {
 		return
@@ -90,6 +126,7 @@ func (d *HostPoolDataSource) Configure(ctx context.Context, req datasource.Confi
 	}
 
 	d.client = providerData.HostPoolsClient
+	d.hostsClient = providerData.HostsClient
 }
 
 func (d *HostPoolDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
@@ -100,6 +137,27 @@ func (d *HostPoolDataSource) Read(ctx context.Context, req datasource.ReadReques
 		return
 	}
 
+	// Resolve the identifier from the name if needed
+	if data.ID.IsNull() {
+		found, err := findByName("host pool", data.Name.ValueString(),
+			func(filter string, offset, limit int32) ([]*fulfillmentv1.HostPool, int32, error) {
+				listResp, err := d.client.List(ctx, &fulfillmentv1.HostPoolsListRequest{
+					Filter: &filter,
+					Offset: &offset,
+					Limit:  &limit,
+				})
+				if err != nil {
+					return nil, 0, err
+				}
+				return listResp.Items, listResp.GetTotal(), nil
+			})
+		if err != nil {
+			resp.Diagnostics.AddAttributeError(path.Root("name"), "Failed to find host pool", err.Error())
+			return
+		}
+		data.ID = types.StringValue(found.Id)
+	}
+
 	getResp, err := d.client.Get(ctx, &fulfillmentv1.HostPoolsGetRequest{
 		Id: data.ID.ValueString(),
 	})
@@ -114,6 +172,7 @@ func (d *HostPoolDataSource) Read(ctx context.Context, req datasource.ReadReques
 	if hostPool.Metadata != nil {
 		data.Name = types.StringValue(hostPool.Metadata.Name)
 	}
+	data.CreatedAt = client.CreatedAtValue(hostPool.Metadata)
 
 	if hostPool.Status != nil {
 		data.State = types.StringValue(hostPool.Status.State.String())
@@ -128,5 +187,35 @@ func (d *HostPoolDataSource) Read(ctx context.Context, req datasource.ReadReques
 		data.Hosts = hostsValue
 	}
 
+	data.ReadyHosts = types.ListNull(types.StringType)
+	data.FailedHosts = types.ListNull(types.StringType)
+	if data.ReadHostStates.ValueBool() && hostPool.Status != nil {
+		readyHosts := []string{}
+		failedHosts := []string{}
+		for _, hostID := range hostPool.Status.Hosts {
+			hostResp, err := d.hostsClient.Get(ctx, &fulfillmentv1.HostsGetRequest{Id: hostID})
+			if err != nil {
+				resp.Diagnostics.AddError("Failed to read host of host pool", fmt.Sprintf("Host %s: %s", hostID, err.Error()))
+				return
+			}
+			if hostResp.Object.Status == nil {
+				continue
+			}
+			switch hostResp.Object.Status.State {
+			case fulfillmentv1.HostState_HOST_STATE_READY:
+				readyHosts = append(readyHosts, hostID)
+			case fulfillmentv1.HostState_HOST_STATE_FAILED:
+				failedHosts = append(failedHosts, hostID)
+			}
+		}
+
+		readyHostsValue, diags := types.ListValueFrom(ctx, types.StringType, readyHosts)
+		resp.Diagnostics.Append(diags...)
+		data.ReadyHosts = readyHostsValue
+		failedHostsValue, diags := types.ListValueFrom(ctx, types.StringType, failedHosts)
+		resp.Diagnostics.Append(diags...)
+		data.FailedHosts = failedHostsValue
+	}
+
 	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
 }
@@ -0,0 +1,189 @@
+/*
+Copyright (c) 2025 Red Hat Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License"); you may not use this file except in compliance with the
+License. You may obtain a copy of the License at
+
+  http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software distributed under the License is distributed on an
+"AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the License for the specific
+language governing permissions and limitations under the License.
+*/
+
+package datasources
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+
+	fulfillmentv1 "github.com/innabox/fulfillment-common/api/fulfillment/v1"
+
+	"github.com/innabox/terraform-provider-osac/internal/client"
+)
+
+// Ensure provider defined types fully satisfy framework interfaces.
+var _ datasource.DataSource = &HostsDataSource{}
+
+func NewHostsDataSource() datasource.DataSource {
+	return &HostsDataSource{}
+}
+
+// HostsDataSource defines the data source implementation.
+type HostsDataSource struct {
+	client fulfillmentv1.HostsClient
+}
+
+// HostsDataSourceModel describes the data source data model.
+type HostsDataSourceModel struct {
+	PowerState  types.String `tfsdk:"power_state"`
+	FailIfEmpty types.Bool   `tfsdk:"fail_if_empty"`
+	Hosts       types.List   `tfsdk:"hosts"`
+}
+
+// HostsItemModel describes a host returned by the data source.
+type HostsItemModel struct {
+	ID         types.String `tfsdk:"id"`
+	Name       types.String `tfsdk:"name"`
+	State      types.String `tfsdk:"state"`
+	PowerState types.String `tfsdk:"power_state"`
+}
+
+var hostsItemAttrTypes = map[string]attr.Type{
+	"id":          types.StringType,
+	"name":        types.StringType,
+	"state":       types.StringType,
+	"power_state": types.StringType,
+}
+
+func (d *HostsDataSource) Metadata(ctx context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_hosts"
+}
+
+func (d *HostsDataSource) Schema(ctx context.Context, req datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Description: "Fetches information about all the existing OSAC hosts.",
+		Attributes: map[string]schema.Attribute{
+			"power_state": schema.StringAttribute{
+				Description: "Only return the hosts currently in this power state (ON, OFF).",
+				Optional:    true,
+				Validators: []validator.String{
+					stringvalidator.OneOf("ON", "OFF", "HOST_POWER_STATE_ON", "HOST_POWER_STATE_OFF"),
+				},
+			},
+			"fail_if_empty": failIfEmptyAttribute("hosts"),
+			"hosts": schema.ListNestedAttribute{
+				Description: "Hosts found, in the order returned by the server. Empty when nothing matches.",
+				Computed:    true,
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: map[string]schema.Attribute{
+						"id": schema.StringAttribute{
+							Description: "Unique identifier of the host.",
+							Computed:    true,
+						},
+						"name": schema.StringAttribute{
+							Description: "Human-friendly name of the host.",
+							Computed:    true,
+						},
+						"state": schema.StringAttribute{
+							Description: "Current state of the host.",
+							Computed:    true,
+						},
+						"power_state": schema.StringAttribute{
+							Description: "Current power state of the host.",
+							Computed:    true,
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func (d *HostsDataSource) Configure(ctx context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	providerData, ok := req.ProviderData.(*client.ProviderData)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Data Source Configure Type",
+			fmt.Sprintf("Expected *client.ProviderData, got: %T", req.ProviderData),
+		)
+		return
+	}
+
+	d.client = providerData.HostsClient
+}
+
+func (d *HostsDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	var data HostsDataSourceModel
+
+	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	hosts, err := listAll(func(offset, limit int32) ([]*fulfillmentv1.Host, int32, error) {
+		listResp, err := d.client.List(ctx, &fulfillmentv1.HostsListRequest{
+			Offset: &offset,
+			Limit:  &limit,
+		})
+		if err != nil {
+			return nil, 0, err
+		}
+		return listResp.Items, listResp.GetTotal(), nil
+	})
+	if err != nil {
+		resp.Diagnostics.AddError("Failed to list hosts", err.Error())
+		return
+	}
+
+	// The power state can be given in the short form (OFF) or as the full enum name (HOST_POWER_STATE_OFF)
+	powerState := ""
+	if !data.PowerState.IsNull() {
+		powerState = strings.TrimPrefix(data.PowerState.ValueString(), "HOST_POWER_STATE_")
+	}
+
+	items := make([]HostsItemModel, 0, len(hosts))
+	for _, host := range hosts {
+		item := HostsItemModel{
+			ID:         types.StringValue(host.Id),
+			Name:       types.StringNull(),
+			State:      types.StringNull(),
+			PowerState: types.StringNull(),
+		}
+		if host.Metadata != nil {
+			item.Name = types.StringValue(host.Metadata.Name)
+		}
+		if host.Status != nil {
+			item.State = types.StringValue(host.Status.State.String())
+			item.PowerState = types.StringValue(host.Status.PowerState.String())
+		}
+		if powerState != "" && (host.Status == nil ||
+			strings.TrimPrefix(host.Status.PowerState.String(), "HOST_POWER_STATE_") != powerState) {
+			continue
+		}
+		items = append(items, item)
+	}
+
+	checkNotEmpty(data.FailIfEmpty, "hosts", len(items), &resp.Diagnostics)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	hostsValue, diags := types.ListValueFrom(ctx, types.ObjectType{AttrTypes: hostsItemAttrTypes}, items)
+	resp.Diagnostics.Append(diags...)
+	data.Hosts = hostsValue
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
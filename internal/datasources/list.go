@@ -0,0 +1,79 @@
+/*
+Copyright (c) 2025 Red Hat Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License"); you may not use this file except in compliance with the
+License. You may obtain a copy of the License at
+
+  http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software distributed under the License is distributed on an
+"AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the License for the specific
+language governing permissions and limitations under the License.
+*/
+
+package datasources
+
+import (
+	"fmt"
+	"regexp"
+
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// listPageSize is the number of items requested per page when a data source lists all the objects of a kind.
+const listPageSize int32 = 100
+
+// listAll fetches all the objects of a kind, one page at a time. The fetch function receives the offset and size of
+// the page and returns its items and the total number of objects. The server doesn't always fill in the total, so
+// paging stops at the first short page, or earlier when a non-zero total says there is nothing left.
+func listAll[T any](fetch func(offset, limit int32) ([]T, int32, error)) ([]T, error) {
+	var all []T
+	offset := int32(0)
+	for {
+		items, total, err := fetch(offset, listPageSize)
+		if err != nil {
+			return nil, err
+		}
+		all = append(all, items...)
+		offset += int32(len(items))
+		if int32(len(items)) < listPageSize || (total > 0 && offset >= total) {
+			return all, nil
+		}
+	}
+}
+
+// compileRegex compiles the optional regular expression of an attribute used to filter the results of a data source.
+// Returns nil when the attribute isn't set.
+func compileRegex(value types.String) (*regexp.Regexp, error) {
+	if value.IsNull() || value.IsUnknown() {
+		return nil, nil
+	}
+	regex, err := regexp.Compile(value.ValueString())
+	if err != nil {
+		return nil, fmt.Errorf("'%s' isn't a valid regular expression: %w", value.ValueString(), err)
+	}
+	return regex, nil
+}
+
+// failIfEmptyAttribute returns the schema of the fail_if_empty attribute of a data source that lists objects. The kind
+// is the plural name of the objects.
+func failIfEmptyAttribute(kind string) schema.BoolAttribute {
+	return schema.BoolAttribute{
+		Description: fmt.Sprintf("Fail instead of returning an empty list when no %s match. Defaults to false.", kind),
+		Optional:    true,
+	}
+}
+
+// checkNotEmpty adds an error when the data source found no objects and its fail_if_empty attribute is true. The kind
+// is the plural name of the objects.
+func checkNotEmpty(failIfEmpty types.Bool, kind string, count int, diags *diag.Diagnostics) {
+	if count > 0 || !failIfEmpty.ValueBool() {
+		return
+	}
+	diags.AddError(
+		fmt.Sprintf("No %s found", kind),
+		fmt.Sprintf("No %s match the arguments of the data source, and fail_if_empty is true.", kind),
+	)
+}
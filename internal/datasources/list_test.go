@@ -0,0 +1,103 @@
+/*
+Copyright (c) 2025 Red Hat Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License"); you may not use this file except in compliance with the
+License. You may obtain a copy of the License at
+
+  http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software distributed under the License is distributed on an
+"AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the License for the specific
+language governing permissions and limitations under the License.
+*/
+
+package datasources
+
+import (
+	"testing"
+)
+
+// fakePages returns a fetch function that serves the given number of objects in pages, reporting the given total.
+// The offsets requested are appended to the given slice.
+func fakePages(count, total int32, offsets *[]int32) func(offset, limit int32) ([]int32, int32, error) {
+	return func(offset, limit int32) ([]int32, int32, error) {
+		*offsets = append(*offsets, offset)
+		var items []int32
+		for i := offset; i < count && i < offset+limit; i++ {
+			items = append(items, i)
+		}
+		return items, total, nil
+	}
+}
+
+func TestListAll(t *testing.T) {
+	tests := []struct {
+		name    string
+		count   int32
+		total   int32
+		offsets []int32
+	}{
+		{
+			name:    "Empty",
+			count:   0,
+			total:   0,
+			offsets: []int32{0},
+		},
+		{
+			name:    "Single page",
+			count:   10,
+			total:   10,
+			offsets: []int32{0},
+		},
+		{
+			name:    "Several pages with total",
+			count:   250,
+			total:   250,
+			offsets: []int32{0, 100, 200},
+		},
+		{
+			name:    "Full last page with total",
+			count:   200,
+			total:   200,
+			offsets: []int32{0, 100},
+		},
+		{
+			name:    "Several pages without total",
+			count:   250,
+			total:   0,
+			offsets: []int32{0, 100, 200},
+		},
+		{
+			name:    "Full last page without total",
+			count:   200,
+			total:   0,
+			offsets: []int32{0, 100, 200},
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			var offsets []int32
+			items, err := listAll(fakePages(test.count, test.total, &offsets))
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if int32(len(items)) != test.count {
+				t.Errorf("expected %d items, got %d", test.count, len(items))
+			}
+			for i, item := range items {
+				if item != int32(i) {
+					t.Fatalf("expected item %d to be %d, got %d", i, i, item)
+				}
+			}
+			if len(offsets) != len(test.offsets) {
+				t.Fatalf("expected offsets %v, got %v", test.offsets, offsets)
+			}
+			for i := range offsets {
+				if offsets[i] != test.offsets[i] {
+					t.Errorf("expected offsets %v, got %v", test.offsets, offsets)
+					break
+				}
+			}
+		})
+	}
+}
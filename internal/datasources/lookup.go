@@ -0,0 +1,33 @@
+/*
+Copyright (c) 2025 Red Hat Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License"); you may not use this file except in compliance with the
+License. You may obtain a copy of the License at
+
+  http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software distributed under the License is distributed on an
+"AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the License for the specific
+language governing permissions and limitations under the License.
+*/
+
+package datasources
+
+import (
+	"github.com/innabox/terraform-provider-osac/internal/client"
+)
+
+// findByName fetches the objects with the given name, one page at a time, and returns the only one. The fetch function
+// receives the filter to send in the List request along with the offset and size of the page.
+func findByName[T client.Named](kind, name string,
+	fetch func(filter string, offset, limit int32) ([]T, int32, error)) (T, error) {
+	filter := client.NameFilter(name)
+	items, err := listAll(func(offset, limit int32) ([]T, int32, error) {
+		return fetch(filter, offset, limit)
+	})
+	if err != nil {
+		var zero T
+		return zero, err
+	}
+	return client.SelectByName(kind, name, items)
+}
@@ -0,0 +1,115 @@
+/*
+Copyright (c) 2025 Red Hat Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License"); you may not use this file except in compliance with the
+License. You may obtain a copy of the License at
+
+  http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software distributed under the License is distributed on an
+"AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the License for the specific
+language governing permissions and limitations under the License.
+*/
+
+package datasources
+
+import (
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"google.golang.org/protobuf/encoding/protojson"
+	"google.golang.org/protobuf/types/known/anypb"
+)
+
+// templateParameterDefinition is implemented by the parameter definitions of both cluster and compute instance
+// templates.
+type templateParameterDefinition interface {
+	GetName() string
+	GetTitle() string
+	GetDescription() string
+	GetRequired() bool
+	GetType() string
+	GetDefault() *anypb.Any
+}
+
+// TemplateParameterModel describes a parameter accepted by a template.
+type TemplateParameterModel struct {
+	Name        types.String `tfsdk:"name"`
+	Title       types.String `tfsdk:"title"`
+	Description types.String `tfsdk:"description"`
+	Required    types.Bool   `tfsdk:"required"`
+	Type        types.String `tfsdk:"type"`
+	Default     types.String `tfsdk:"default"`
+}
+
+var templateParameterAttrTypes = map[string]attr.Type{
+	"name":        types.StringType,
+	"title":       types.StringType,
+	"description": types.StringType,
+	"required":    types.BoolType,
+	"type":        types.StringType,
+	"default":     types.StringType,
+}
+
+// templateParametersAttribute returns the schema of the computed list of parameters accepted by a template.
+func templateParametersAttribute() schema.ListNestedAttribute {
+	return schema.ListNestedAttribute{
+		Description: "Parameters accepted by the template.",
+		Computed:    true,
+		NestedObject: schema.NestedAttributeObject{
+			Attributes: map[string]schema.Attribute{
+				"name": schema.StringAttribute{
+					Description: "Name of the parameter, as used in template_parameters.",
+					Computed:    true,
+				},
+				"title": schema.StringAttribute{
+					Description: "Human-friendly short description of the parameter.",
+					Computed:    true,
+				},
+				"description": schema.StringAttribute{
+					Description: "Human-friendly long description of the parameter.",
+					Computed:    true,
+				},
+				"required": schema.BoolAttribute{
+					Description: "Whether a value must be given for the parameter.",
+					Computed:    true,
+				},
+				"type": schema.StringAttribute{
+					Description: "Type of the value of the parameter, like 'type.googleapis.com/google.protobuf.StringValue'.",
+					Computed:    true,
+				},
+				"default": schema.StringAttribute{
+					Description: "Default value of the parameter serialized as JSON, null when there is none. Use " +
+						"jsondecode to read it.",
+					Computed: true,
+				},
+			},
+		},
+	}
+}
+
+// templateParameterModels converts the parameter definitions of a template to the models of the parameters
+// attribute. Defaults that can't be decoded are reported as null.
+func templateParameterModels[T templateParameterDefinition](definitions []T) []TemplateParameterModel {
+	models := make([]TemplateParameterModel, 0, len(definitions))
+	for _, definition := range definitions {
+		model := TemplateParameterModel{
+			Name:        types.StringValue(definition.GetName()),
+			Title:       types.StringValue(definition.GetTitle()),
+			Description: types.StringValue(definition.GetDescription()),
+			Required:    types.BoolValue(definition.GetRequired()),
+			Type:        types.StringValue(definition.GetType()),
+			Default:     types.StringNull(),
+		}
+		if definition.GetDefault() != nil {
+			// The JSON form of the wrapper types is the bare value, like "abc" or 3
+			if value, err := definition.GetDefault().UnmarshalNew(); err == nil {
+				if encoded, err := protojson.Marshal(value); err == nil {
+					model.Default = types.StringValue(string(encoded))
+				}
+			}
+		}
+		models = append(models, model)
+	}
+	return models
+}
@@ -15,14 +15,25 @@ package provider
 
 import (
 	"context"
+	"crypto/x509"
+	"fmt"
 	"log/slog"
 	"os"
+	"strings"
+	"time"
+	"unicode"
 
+	"github.com/hashicorp/terraform-plugin-framework-validators/float64validator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/listvalidator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
 	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/path"
 	"github.com/hashicorp/terraform-plugin-framework/provider"
 	"github.com/hashicorp/terraform-plugin-framework/provider/schema"
 	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
 	"github.com/hashicorp/terraform-plugin-framework/types"
+	"google.golang.org/grpc"
 
 	fulfillmentv1 "github.com/innabox/fulfillment-common/api/fulfillment/v1"
 	"github.com/innabox/fulfillment-common/auth"
@@ -32,6 +43,7 @@ import (
 	"github.com/innabox/terraform-provider-osac/internal/client"
 	"github.com/innabox/terraform-provider-osac/internal/datasources"
 	"github.com/innabox/terraform-provider-osac/internal/resources"
+	"github.com/innabox/terraform-provider-osac/internal/waiter"
 )
 
 // Ensure OsacProvider satisfies various provider interfaces.
@@ -42,15 +54,43 @@ type OsacProvider struct {
 	version string
 }
 
+// OAuth2 flows that can be selected with the auth_flow attribute.
+const (
+	authFlowCredentials  = "credentials"
+	authFlowRefreshToken = "refresh_token"
+)
+
 // OsacProviderModel describes the provider data model.
 type OsacProviderModel struct {
 	Endpoint     types.String `tfsdk:"endpoint"`
 	Token        types.String `tfsdk:"token"`
+	TokenFile    types.String `tfsdk:"token_file"`
 	ClientID     types.String `tfsdk:"client_id"`
 	ClientSecret types.String `tfsdk:"client_secret"`
 	Issuer       types.String `tfsdk:"issuer"`
+	AuthFlow     types.String `tfsdk:"auth_flow"`
+	RefreshToken types.String `tfsdk:"refresh_token"`
+	Scopes       types.List   `tfsdk:"scopes"`
 	Insecure     types.Bool   `tfsdk:"insecure"`
 	Plaintext    types.Bool   `tfsdk:"plaintext"`
+	CACert       types.String `tfsdk:"ca_cert"`
+	// Connection behavior
+	KeepaliveTime types.String `tfsdk:"keepalive_time"`
+	// Waiting behavior
+	WaitForReady          types.Bool    `tfsdk:"wait_for_ready"`
+	SlowWarningAfter      types.String  `tfsdk:"slow_warning_after"`
+	PollInterval          types.String  `tfsdk:"poll_interval"`
+	PollMinInterval       types.String  `tfsdk:"poll_min_interval"`
+	PollBackoffMultiplier types.Float64 `tfsdk:"poll_backoff_multiplier"`
+	PollMaxInterval       types.String  `tfsdk:"poll_max_interval"`
+	// Request behavior
+	MaxConcurrentRequests types.Int64  `tfsdk:"max_concurrent_requests"`
+	GrpcWaitForReady      types.Bool   `tfsdk:"grpc_wait_for_ready"`
+	MaxRetries            types.Int64  `tfsdk:"max_retries"`
+	RetryMaxBackoff       types.String `tfsdk:"retry_max_backoff"`
+	RequestTimeout        types.String `tfsdk:"request_timeout"`
+	UserAgentSuffix       types.String `tfsdk:"user_agent_suffix"`
+	RequestHeaders        types.Map    `tfsdk:"request_headers"`
 }
 
 func New(version string) func() provider.Provider {
@@ -73,35 +113,76 @@ func (p *OsacProvider) Schema(ctx context.Context, req provider.SchemaRequest, r
 
 ## Authentication
 
-The provider supports two authentication methods:
+The provider supports three authentication methods:
 
-1. **Token authentication**: Provide a static access token via the ` + "`token`" + ` attribute.
+1. **Token authentication**: Provide a static access token via the ` + "`token`" + ` attribute, or the path of a file
+   containing it via ` + "`token_file`" + `.
 2. **OAuth2 client credentials**: Provide ` + "`client_id`" + `, ` + "`client_secret`" + `, and ` + "`issuer`" + ` attributes.
+3. **OAuth2 refresh token**: Set ` + "`auth_flow`" + ` to ` + "`\"refresh_token\"`" + ` and provide ` + "`refresh_token`" + `,
+   ` + "`client_id`" + ` and ` + "`issuer`" + `.
 
-You must use one of these methods, not both.`,
+You must use one of these methods, not several. Each attribute can also be set with an environment variable, but the
+environment variables of a method are ignored when the configuration uses another one.`,
 		Attributes: map[string]schema.Attribute{
 			"endpoint": schema.StringAttribute{
-				Description: "The gRPC endpoint address of the fulfillment API (e.g., api.example.com:443).",
-				Required:    true,
+				Description: "The gRPC endpoint address of the fulfillment API (e.g., api.example.com:443). Can also be " +
+					"set with the OSAC_ENDPOINT environment variable.",
+				Optional: true,
 			},
 			"token": schema.StringAttribute{
-				Description: "Access token for authentication. Use this OR the OAuth2 client credentials (client_id, client_secret, issuer), not both.",
-				Optional:    true,
-				Sensitive:   true,
+				Description: "Access token for authentication. Use this OR the OAuth2 client credentials (client_id, client_secret, issuer), not both. " +
+					"Can also be set with the OSAC_TOKEN environment variable.",
+				Optional:  true,
+				Sensitive: true,
+			},
+			"token_file": schema.StringAttribute{
+				Description: "Path of a file containing the access token, which is read when the provider is configured. " +
+					"Keeps the token out of the configuration. Can't be used with token. Can also be set with the " +
+					"OSAC_TOKEN_FILE environment variable.",
+				Optional: true,
 			},
 			"client_id": schema.StringAttribute{
-				Description: "OAuth2 client ID for authentication. Required if not using token authentication.",
-				Optional:    true,
-				Sensitive:   true,
+				Description: "OAuth2 client ID for authentication. Required if not using token authentication. Can also be set " +
+					"with the OSAC_CLIENT_ID environment variable.",
+				Optional:  true,
+				Sensitive: true,
 			},
 			"client_secret": schema.StringAttribute{
-				Description: "OAuth2 client secret for authentication. Required if not using token authentication.",
-				Optional:    true,
-				Sensitive:   true,
+				Description: "OAuth2 client secret for authentication. Required if not using token authentication. Can also be " +
+					"set with the OSAC_CLIENT_SECRET environment variable.",
+				Optional:  true,
+				Sensitive: true,
 			},
 			"issuer": schema.StringAttribute{
-				Description: "OAuth2 issuer URL for token endpoint discovery. Required if not using token authentication.",
+				Description: "OAuth2 issuer URL for token endpoint discovery. Required if not using token authentication. Can " +
+					"also be set with the OSAC_ISSUER environment variable.",
+				Optional: true,
+			},
+			"auth_flow": schema.StringAttribute{
+				Description: "OAuth2 flow used to obtain access tokens: \"credentials\" uses client_id and client_secret, " +
+					"\"refresh_token\" uses a refresh token obtained beforehand, for example by logging in with the " +
+					"command line tool. Defaults to \"credentials\".",
+				Optional: true,
+				Validators: []validator.String{
+					stringvalidator.OneOf(authFlowCredentials, authFlowRefreshToken),
+				},
+			},
+			"refresh_token": schema.StringAttribute{
+				Description: "OAuth2 refresh token used to obtain access tokens when auth_flow is \"refresh_token\". " +
+					"Requires client_id and issuer; client_secret is only needed for confidential clients. Can also be " +
+					"set with the OSAC_REFRESH_TOKEN environment variable.",
+				Optional:  true,
+				Sensitive: true,
+			},
+			"scopes": schema.ListAttribute{
+				Description: "OAuth2 scopes requested with the access tokens, for identity providers that require " +
+					"specific scopes for the fulfillment API. Defaults to the scopes chosen by the issuer. Can't be " +
+					"used with token authentication.",
+				ElementType: types.StringType,
 				Optional:    true,
+				Validators: []validator.List{
+					listvalidator.ValueStringsAre(stringvalidator.LengthAtLeast(1)),
+				},
 			},
 			"insecure": schema.BoolAttribute{
 				Description: "Skip TLS certificate verification. Not recommended for production.",
@@ -111,6 +192,102 @@ You must use one of these methods, not both.`,
 				Description: "Use plaintext connection (no TLS). Not recommended for production.",
 				Optional:    true,
 			},
+			"ca_cert": schema.StringAttribute{
+				Description: "PEM encoded certificates of the CAs trusted to verify the certificate of the API, in " +
+					"addition to the system ones. Use it when the API is served with a certificate of a private CA. " +
+					"Can't be used with insecure or plaintext.",
+				Optional: true,
+			},
+			"keepalive_time": schema.StringAttribute{
+				Description: "Interval (e.g. \"30s\") of the keepalive pings sent while calls are in progress, which " +
+					"keeps slow calls from being dropped by proxies. gRPC doesn't send pings more often than every " +
+					"10s. Disabled by default.",
+				Optional: true,
+			},
+			"wait_for_ready": schema.BoolAttribute{
+				Description: "Default for the wait_for_ready attribute of resources: whether create and update wait " +
+					"for objects to become ready. Set it to false to make every resource fire-and-forget, for example " +
+					"when readiness is checked outside of Terraform. Defaults to true.",
+				Optional: true,
+			},
+			"slow_warning_after": schema.StringAttribute{
+				Description: "Duration (e.g. \"15m\") after which a warning is logged when a resource is still not ready. " +
+					"Waiting continues until the operation timeout. Disabled by default.",
+				Optional: true,
+			},
+			"poll_interval": schema.StringAttribute{
+				Description: fmt.Sprintf("Fixed time between checks of the state of objects while waiting for them, "+
+					"like \"30s\". Must be at least %s and less than %s. When not set, the checks back off from "+
+					"poll_min_interval to %s. The poll_interval attribute of data sources overrides it.",
+					waiter.MinAllowedPollInterval, waiter.MaxAllowedPollInterval, waiter.MaxMinPollInterval),
+				Optional: true,
+			},
+			"poll_min_interval": schema.StringAttribute{
+				Description: fmt.Sprintf("Minimum time between checks of the state of objects while waiting for them, "+
+					"like \"5s\", when poll_interval isn't set. Must be between %s and %s. Defaults to %s.",
+					waiter.MinAllowedPollInterval, waiter.MaxMinPollInterval, waiter.DefaultMinPollInterval),
+				Optional: true,
+			},
+			"poll_backoff_multiplier": schema.Float64Attribute{
+				Description: fmt.Sprintf("Factor, between 1 and %g, by which the time between checks of the state of "+
+					"an object grows after every check, with some random jitter, so that large applies don't poll "+
+					"the API in lockstep. Defaults to 1, which keeps the constant poll_interval.",
+					waiter.MaxBackoffMultiplier),
+				Optional: true,
+				Validators: []validator.Float64{
+					float64validator.Between(1, waiter.MaxBackoffMultiplier),
+				},
+			},
+			"poll_max_interval": schema.StringAttribute{
+				Description: fmt.Sprintf("Longest time between checks of the state of an object when "+
+					"poll_backoff_multiplier is set, like \"1m\". Must be at least poll_interval. Defaults to %s.",
+					waiter.DefaultMaxPollInterval),
+				Optional: true,
+			},
+			"max_concurrent_requests": schema.Int64Attribute{
+				Description: fmt.Sprintf("Maximum number of concurrent requests sent by a single data source that "+
+					"fetches several objects. Defaults to %d.", client.DefaultMaxConcurrentRequests),
+				Optional: true,
+			},
+			"grpc_wait_for_ready": schema.BoolAttribute{
+				Description: "Queue API calls while the connection is down instead of failing them immediately, " +
+					"using the gRPC wait-for-ready call option. A queued call waits until the connection is back or " +
+					"its deadline expires. Calls made by resources have no deadline of their own, so they can wait " +
+					"until the operation is cancelled. Defaults to false.",
+				Optional: true,
+			},
+			"max_retries": schema.Int64Attribute{
+				Description: fmt.Sprintf("Maximum number of times a read, list or delete call is retried with an "+
+					"exponential backoff when it fails with a transient error (Unavailable or ResourceExhausted). "+
+					"A rate limited call waits for the delay given by the server, when there is one. Set it to 0 to "+
+					"disable retries. Defaults to %d.", client.DefaultMaxRetries),
+				Optional: true,
+			},
+			"retry_max_backoff": schema.StringAttribute{
+				Description: fmt.Sprintf("Longest time (e.g. \"10s\") waited between two retries of a call. The "+
+					"wait starts at %s and doubles with each retry until it reaches this value. Must be more than "+
+					"%s. Defaults to %s.", client.RetryInitialBackoff, client.RetryInitialBackoff,
+					client.DefaultRetryMaxBackoff),
+				Optional: true,
+			},
+			"request_timeout": schema.StringAttribute{
+				Description: "Maximum duration (e.g. \"1m\") of a single call to the API. A read, list or delete call " +
+					"that takes longer is cancelled and retried like other transient errors, so that a stalled " +
+					"request doesn't block the apply until the operation timeout. Disabled by default.",
+				Optional: true,
+			},
+			"user_agent_suffix": schema.StringAttribute{
+				Description: "Text appended to the user agent of the provider, which is sent with every call, to " +
+					"identify the calls made by a pipeline or a team in the logs of the server.",
+				Optional: true,
+			},
+			"request_headers": schema.MapAttribute{
+				Description: "Headers sent as metadata of every call, for example to correlate the calls made by " +
+					"Terraform in the logs of the server. Names are case insensitive; names starting with grpc-, " +
+					"ending with -bin and authorization are reserved.",
+				ElementType: types.StringType,
+				Optional:    true,
+			},
 		},
 	}
 }
@@ -123,18 +300,251 @@ func (p *OsacProvider) Configure(ctx context.Context, req provider.ConfigureRequ
 		return
 	}
 
+	if !config.Token.IsNull() && !config.TokenFile.IsNull() {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("token_file"),
+			"Invalid authentication configuration",
+			"Provide either 'token' or 'token_file', not both.",
+		)
+		return
+	}
+
+	// Attributes set in the configuration take precedence over the environment
+	config.Endpoint = stringFromEnv(config.Endpoint, "OSAC_ENDPOINT")
+	authFromEnv(&config)
+	if !config.TokenFile.IsNull() {
+		token, err := readTokenFile(config.TokenFile.ValueString())
+		if err != nil {
+			resp.Diagnostics.AddAttributeError(path.Root("token_file"), "Invalid token_file value", err.Error())
+			return
+		}
+		config.Token = types.StringValue(token)
+	}
+
+	if config.Endpoint.ValueString() == "" {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("endpoint"),
+			"Missing endpoint",
+			"Set the 'endpoint' attribute or the OSAC_ENDPOINT environment variable to the address of the fulfillment API.",
+		)
+		return
+	}
+
+	// Parse waiting settings
+	var waitSettings client.WaitSettings
+	if !config.WaitForReady.IsNull() {
+		waitSettings.Disabled = !config.WaitForReady.ValueBool()
+	}
+	if !config.SlowWarningAfter.IsNull() {
+		slowWarningAfter, err := time.ParseDuration(config.SlowWarningAfter.ValueString())
+		if err != nil || slowWarningAfter <= 0 {
+			resp.Diagnostics.AddAttributeError(
+				path.Root("slow_warning_after"),
+				"Invalid slow_warning_after value",
+				fmt.Sprintf("Expected a positive duration such as \"15m\", got %q.", config.SlowWarningAfter.ValueString()),
+			)
+			return
+		}
+		waitSettings.SlowWarningAfter = slowWarningAfter
+	}
+	if !config.PollInterval.IsNull() {
+		pollInterval, err := waiter.ParsePollInterval(config.PollInterval.ValueString())
+		if err != nil {
+			resp.Diagnostics.AddAttributeError(path.Root("poll_interval"), "Invalid poll_interval value", err.Error())
+			return
+		}
+		waitSettings.PollInterval = pollInterval
+	}
+	if !config.PollMinInterval.IsNull() {
+		pollMinInterval, err := waiter.ParsePollInterval(config.PollMinInterval.ValueString())
+		if err != nil {
+			resp.Diagnostics.AddAttributeError(path.Root("poll_min_interval"), "Invalid poll_min_interval value",
+				err.Error())
+			return
+		}
+		if pollMinInterval > waiter.MaxMinPollInterval {
+			resp.Diagnostics.AddAttributeError(
+				path.Root("poll_min_interval"),
+				"Invalid poll_min_interval value",
+				fmt.Sprintf("The poll_min_interval must be at most %s, but it is %s.", waiter.MaxMinPollInterval,
+					pollMinInterval),
+			)
+			return
+		}
+		if waitSettings.PollInterval > 0 {
+			resp.Diagnostics.AddAttributeError(
+				path.Root("poll_min_interval"),
+				"Invalid poll_min_interval value",
+				"The poll_min_interval attribute only applies when poll_interval isn't set.",
+			)
+			return
+		}
+		waitSettings.MinPollInterval = pollMinInterval
+	}
+	if !config.PollBackoffMultiplier.IsNull() {
+		waitSettings.BackoffMultiplier = config.PollBackoffMultiplier.ValueFloat64()
+	}
+	if !config.PollMaxInterval.IsNull() {
+		if waitSettings.BackoffMultiplier <= 1 {
+			resp.Diagnostics.AddAttributeError(
+				path.Root("poll_max_interval"),
+				"Invalid poll_max_interval value",
+				"The poll_max_interval attribute requires a poll_backoff_multiplier greater than 1.",
+			)
+			return
+		}
+		pollMaxInterval, err := waiter.ParsePollInterval(config.PollMaxInterval.ValueString())
+		if err != nil {
+			resp.Diagnostics.AddAttributeError(path.Root("poll_max_interval"), "Invalid poll_max_interval value",
+				err.Error())
+			return
+		}
+		pollInterval := waitSettings.PollInterval
+		if pollInterval == 0 {
+			pollInterval = waiter.DefaultPollInterval
+		}
+		if pollMaxInterval < pollInterval {
+			resp.Diagnostics.AddAttributeError(
+				path.Root("poll_max_interval"),
+				"Invalid poll_max_interval value",
+				fmt.Sprintf("The poll_max_interval (%s) must be at least the poll_interval (%s).", pollMaxInterval,
+					pollInterval),
+			)
+			return
+		}
+		waitSettings.MaxPollInterval = pollMaxInterval
+	}
+
+	maxConcurrentRequests := client.DefaultMaxConcurrentRequests
+	if !config.MaxConcurrentRequests.IsNull() {
+		if config.MaxConcurrentRequests.ValueInt64() < 1 {
+			resp.Diagnostics.AddAttributeError(
+				path.Root("max_concurrent_requests"),
+				"Invalid max_concurrent_requests value",
+				fmt.Sprintf("Expected a value of at least 1, got %d.", config.MaxConcurrentRequests.ValueInt64()),
+			)
+			return
+		}
+		maxConcurrentRequests = int(config.MaxConcurrentRequests.ValueInt64())
+	}
+
+	maxRetries := client.DefaultMaxRetries
+	if !config.MaxRetries.IsNull() {
+		if config.MaxRetries.ValueInt64() < 0 {
+			resp.Diagnostics.AddAttributeError(
+				path.Root("max_retries"),
+				"Invalid max_retries value",
+				fmt.Sprintf("Expected a value of at least 0, got %d.", config.MaxRetries.ValueInt64()),
+			)
+			return
+		}
+		maxRetries = int(config.MaxRetries.ValueInt64())
+	}
+
+	retryMaxBackoff := client.DefaultRetryMaxBackoff
+	if !config.RetryMaxBackoff.IsNull() {
+		var err error
+		retryMaxBackoff, err = time.ParseDuration(config.RetryMaxBackoff.ValueString())
+		if err != nil || retryMaxBackoff <= client.RetryInitialBackoff {
+			resp.Diagnostics.AddAttributeError(
+				path.Root("retry_max_backoff"),
+				"Invalid retry_max_backoff value",
+				fmt.Sprintf("Expected a duration of more than %s such as \"10s\", got %q.", client.RetryInitialBackoff,
+					config.RetryMaxBackoff.ValueString()),
+			)
+			return
+		}
+	}
+
+	// Parse keepalive settings
+	var keepaliveTime time.Duration
+	if !config.KeepaliveTime.IsNull() {
+		var err error
+		keepaliveTime, err = time.ParseDuration(config.KeepaliveTime.ValueString())
+		if err != nil || keepaliveTime <= 0 {
+			resp.Diagnostics.AddAttributeError(
+				path.Root("keepalive_time"),
+				"Invalid keepalive_time value",
+				fmt.Sprintf("Expected a positive duration such as \"30s\", got %q.", config.KeepaliveTime.ValueString()),
+			)
+			return
+		}
+	}
+
+	var requestTimeout time.Duration
+	if !config.RequestTimeout.IsNull() {
+		var err error
+		requestTimeout, err = time.ParseDuration(config.RequestTimeout.ValueString())
+		if err != nil || requestTimeout <= 0 {
+			resp.Diagnostics.AddAttributeError(
+				path.Root("request_timeout"),
+				"Invalid request_timeout value",
+				fmt.Sprintf("Expected a positive duration such as \"1m\", got %q.", config.RequestTimeout.ValueString()),
+			)
+			return
+		}
+	}
+
+	var requestHeaders map[string]string
+	if !config.RequestHeaders.IsNull() {
+		resp.Diagnostics.Append(config.RequestHeaders.ElementsAs(ctx, &requestHeaders, false)...)
+		if resp.Diagnostics.HasError() {
+			return
+		}
+		for name := range requestHeaders {
+			if err := client.CheckHeaderName(name); err != nil {
+				resp.Diagnostics.AddAttributeError(
+					path.Root("request_headers").AtMapKey(name),
+					"Invalid request_headers value",
+					err.Error(),
+				)
+			}
+		}
+		if resp.Diagnostics.HasError() {
+			return
+		}
+	}
+
 	// Create a logger
 	logger := slog.New(slog.NewTextHandler(os.Stderr, &slog.HandlerOptions{
 		Level: slog.LevelWarn,
 	}))
 
 	// Determine authentication method
+	authFlow := authFlowCredentials
+	if !config.AuthFlow.IsNull() {
+		authFlow = config.AuthFlow.ValueString()
+	}
 	hasToken := !config.Token.IsNull() && config.Token.ValueString() != ""
+	hasRefreshToken := !config.RefreshToken.IsNull() && config.RefreshToken.ValueString() != ""
 	hasOAuth := !config.ClientID.IsNull() && config.ClientID.ValueString() != "" &&
-		!config.ClientSecret.IsNull() && config.ClientSecret.ValueString() != "" &&
 		!config.Issuer.IsNull() && config.Issuer.ValueString() != ""
+	if authFlow == authFlowRefreshToken {
+		hasOAuth = hasOAuth && hasRefreshToken
+	} else {
+		hasOAuth = hasOAuth && !config.ClientSecret.IsNull() && config.ClientSecret.ValueString() != ""
+	}
 
 	// Validate authentication configuration
+	if hasRefreshToken && authFlow != authFlowRefreshToken {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("refresh_token"),
+			"Invalid authentication configuration",
+			"'refresh_token' is only used when 'auth_flow' is \"refresh_token\".",
+		)
+		return
+	}
+
+	// Scopes are sent to the issuer, so they mean nothing for a static token
+	if hasToken && !config.Scopes.IsNull() {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("scopes"),
+			"Invalid authentication configuration",
+			"'scopes' is only used with OAuth2 authentication, not with 'token'.",
+		)
+		return
+	}
+
 	if hasToken && hasOAuth {
 		resp.Diagnostics.AddError(
 			"Invalid authentication configuration",
@@ -144,6 +554,13 @@ func (p *OsacProvider) Configure(ctx context.Context, req provider.ConfigureRequ
 	}
 
 	if !hasToken && !hasOAuth {
+		if authFlow == authFlowRefreshToken {
+			resp.Diagnostics.AddError(
+				"Missing authentication configuration",
+				"The \"refresh_token\" flow requires 'refresh_token', 'client_id' and 'issuer'.",
+			)
+			return
+		}
 		resp.Diagnostics.AddError(
 			"Missing authentication configuration",
 			"Provide either 'token' for token authentication OR 'client_id', 'client_secret', and 'issuer' for OAuth2 authentication.",
@@ -153,6 +570,7 @@ func (p *OsacProvider) Configure(ctx context.Context, req provider.ConfigureRequ
 
 	// Create token source based on authentication method
 	var tokenSource auth.TokenSource
+	var tokenStore auth.TokenStore
 	var err error
 
 	if hasToken {
@@ -171,8 +589,7 @@ func (p *OsacProvider) Configure(ctx context.Context, req provider.ConfigureRequ
 			return
 		}
 	} else {
-		// Use OAuth2 client credentials flow
-		tokenStore, err := auth.NewMemoryTokenStore().
+		tokenStore, err = auth.NewMemoryTokenStore().
 			SetLogger(logger).
 			Build()
 		if err != nil {
@@ -183,12 +600,38 @@ func (p *OsacProvider) Configure(ctx context.Context, req provider.ConfigureRequ
 			return
 		}
 
+		// Use OAuth2 client credentials flow, or the refresh token. The refresh token is put in the store, so that
+		// the token source exchanges it for access tokens instead of running the flow, which needs a browser.
+		flow := oauth.CredentialsFlow
+		if authFlow == authFlowRefreshToken {
+			flow = oauth.CodeFlow
+			err = tokenStore.Save(ctx, &auth.Token{
+				Refresh: config.RefreshToken.ValueString(),
+			})
+			if err != nil {
+				resp.Diagnostics.AddError(
+					"Failed to store refresh token",
+					err.Error(),
+				)
+				return
+			}
+		}
+
+		var scopes []string
+		if !config.Scopes.IsNull() {
+			resp.Diagnostics.Append(config.Scopes.ElementsAs(ctx, &scopes, false)...)
+			if resp.Diagnostics.HasError() {
+				return
+			}
+		}
+
 		tokenSource, err = oauth.NewTokenSource().
 			SetLogger(logger).
-			SetFlow(oauth.CredentialsFlow).
+			SetFlow(flow).
 			SetIssuer(config.Issuer.ValueString()).
 			SetClientId(config.ClientID.ValueString()).
 			SetClientSecret(config.ClientSecret.ValueString()).
+			SetScopes(scopes...).
 			SetStore(tokenStore).
 			Build()
 		if err != nil {
@@ -214,6 +657,40 @@ func (p *OsacProvider) Configure(ctx context.Context, req provider.ConfigureRequ
 		grpcBuilder.SetPlaintext(true)
 	}
 
+	if !config.CACert.IsNull() {
+		if config.Insecure.ValueBool() || config.Plaintext.ValueBool() {
+			resp.Diagnostics.AddAttributeError(
+				path.Root("ca_cert"),
+				"Invalid TLS configuration",
+				"'ca_cert' can't be used with 'insecure' or 'plaintext', which disable the verification of the certificate.",
+			)
+			return
+		}
+		caPool, err := x509.SystemCertPool()
+		if err != nil {
+			caPool = x509.NewCertPool()
+		}
+		if !caPool.AppendCertsFromPEM([]byte(config.CACert.ValueString())) {
+			resp.Diagnostics.AddAttributeError(
+				path.Root("ca_cert"),
+				"Invalid ca_cert value",
+				"Expected one or more PEM encoded certificates, but none could be parsed.",
+			)
+			return
+		}
+		grpcBuilder.SetCaPool(caPool)
+	}
+
+	if keepaliveTime > 0 {
+		grpcBuilder.SetKeepAlive(keepaliveTime)
+	}
+
+	if !config.UserAgentSuffix.IsNull() {
+		grpcBuilder.SetUserAgent(
+			fmt.Sprintf("terraform-provider-osac/%s %s", p.version, config.UserAgentSuffix.ValueString()),
+		)
+	}
+
 	conn, err := grpcBuilder.Build()
 	if err != nil {
 		resp.Diagnostics.AddError(
@@ -223,22 +700,96 @@ func (p *OsacProvider) Configure(ctx context.Context, req provider.ConfigureRequ
 		return
 	}
 
+	// Wrap the connection so that calls are logged, retried on transient errors and expired tokens, and recover from
+	// backend restarts
+	interceptors := []grpc.UnaryClientInterceptor{
+		client.LoggingInterceptor(),
+		client.RetryInterceptor(maxRetries, retryMaxBackoff),
+	}
+	if tokenStore != nil {
+		// Before the timeout, so that the call retried with a new token gets its own deadline
+		interceptors = append(interceptors, client.TokenRefreshInterceptor(tokenStore))
+	}
+	if requestTimeout > 0 {
+		// After the retries, so that each attempt gets its own deadline
+		interceptors = append(interceptors, client.TimeoutInterceptor(requestTimeout))
+	}
+	interceptors = append(interceptors, client.ReconnectInterceptor(client.DefaultReconnectTimeout))
+	if config.GrpcWaitForReady.ValueBool() {
+		interceptors = append(interceptors, client.WaitForReadyInterceptor())
+	}
+	if len(requestHeaders) > 0 {
+		interceptors = append(interceptors, client.HeadersInterceptor(requestHeaders))
+	}
+	clientConn := client.NewConn(conn, interceptors...)
+
 	// Create provider data with all service clients
 	providerData := &client.ProviderData{
 		Conn:                           conn,
-		ClustersClient:                 fulfillmentv1.NewClustersClient(conn),
-		ClusterTemplatesClient:         fulfillmentv1.NewClusterTemplatesClient(conn),
-		ComputeInstancesClient:         fulfillmentv1.NewComputeInstancesClient(conn),
-		ComputeInstanceTemplatesClient: fulfillmentv1.NewComputeInstanceTemplatesClient(conn),
-		HostsClient:                    fulfillmentv1.NewHostsClient(conn),
-		HostClassesClient:              fulfillmentv1.NewHostClassesClient(conn),
-		HostPoolsClient:                fulfillmentv1.NewHostPoolsClient(conn),
+		ClustersClient:                 fulfillmentv1.NewClustersClient(clientConn),
+		ClusterTemplatesClient:         fulfillmentv1.NewClusterTemplatesClient(clientConn),
+		ComputeInstancesClient:         fulfillmentv1.NewComputeInstancesClient(clientConn),
+		ComputeInstanceTemplatesClient: fulfillmentv1.NewComputeInstanceTemplatesClient(clientConn),
+		HostsClient:                    fulfillmentv1.NewHostsClient(clientConn),
+		HostClassesClient:              fulfillmentv1.NewHostClassesClient(clientConn),
+		HostPoolsClient:                fulfillmentv1.NewHostPoolsClient(clientConn),
+		Wait:                           waitSettings,
+		MaxConcurrentRequests:          maxConcurrentRequests,
 	}
 
 	resp.DataSourceData = providerData
 	resp.ResourceData = providerData
 }
 
+// readTokenFile reads an access token from a file. Trailing whitespace, like the final newline, is removed.
+func readTokenFile(name string) (string, error) {
+	data, err := os.ReadFile(name)
+	if err != nil {
+		return "", fmt.Errorf("failed to read the token: %w", err)
+	}
+	token := strings.TrimRightFunc(string(data), unicode.IsSpace)
+	if token == "" {
+		return "", fmt.Errorf("file '%s' doesn't contain a token", name)
+	}
+	return token, nil
+}
+
+// authFromEnv fills the authentication attributes that aren't set in the configuration from the environment. The
+// environment variables of a method are only used when the configuration doesn't use the other one, so that, for
+// example, OSAC_TOKEN doesn't conflict with OAuth2 credentials given in the configuration. When the token comes from
+// the environment, OSAC_TOKEN_FILE takes precedence over OSAC_TOKEN.
+func authFromEnv(config *OsacProviderModel) {
+	hasToken := !config.Token.IsNull() || !config.TokenFile.IsNull()
+	hasOAuth := !config.ClientID.IsNull() || !config.ClientSecret.IsNull() || !config.Issuer.IsNull() ||
+		!config.AuthFlow.IsNull() || !config.RefreshToken.IsNull() || !config.Scopes.IsNull()
+	if !hasOAuth {
+		if config.Token.IsNull() {
+			config.TokenFile = stringFromEnv(config.TokenFile, "OSAC_TOKEN_FILE")
+		}
+		if config.TokenFile.IsNull() {
+			config.Token = stringFromEnv(config.Token, "OSAC_TOKEN")
+		}
+	}
+	if !hasToken {
+		config.ClientID = stringFromEnv(config.ClientID, "OSAC_CLIENT_ID")
+		config.ClientSecret = stringFromEnv(config.ClientSecret, "OSAC_CLIENT_SECRET")
+		config.Issuer = stringFromEnv(config.Issuer, "OSAC_ISSUER")
+		config.RefreshToken = stringFromEnv(config.RefreshToken, "OSAC_REFRESH_TOKEN")
+	}
+}
+
+// stringFromEnv returns the value of the attribute, or the value of the environment variable when the attribute isn't
+// set in the configuration.
+func stringFromEnv(value types.String, name string) types.String {
+	if !value.IsNull() {
+		return value
+	}
+	if env, ok := os.LookupEnv(name); ok {
+		return types.StringValue(env)
+	}
+	return value
+}
+
 func (p *OsacProvider) Resources(ctx context.Context) []func() resource.Resource {
 	return []func() resource.Resource{
 		resources.NewClusterResource,
@@ -251,11 +802,18 @@ func (p *OsacProvider) Resources(ctx context.Context) []func() resource.Resource
 func (p *OsacProvider) DataSources(ctx context.Context) []func() datasource.DataSource {
 	return []func() datasource.DataSource{
 		datasources.NewClusterDataSource,
+		datasources.NewClustersDataSource,
+		datasources.NewClusterCredentialsDataSource,
 		datasources.NewClusterTemplateDataSource,
+		datasources.NewClusterTemplatesDataSource,
+		datasources.NewCompatibleTemplatesDataSource,
 		datasources.NewComputeInstanceDataSource,
 		datasources.NewComputeInstanceTemplateDataSource,
 		datasources.NewHostDataSource,
+		datasources.NewHostsDataSource,
 		datasources.NewHostClassDataSource,
+		datasources.NewHostClassesDataSource,
 		datasources.NewHostPoolDataSource,
+		datasources.NewConnectivityDataSource,
 	}
 }
@@ -0,0 +1,92 @@
+/*
+Copyright (c) 2025 Red Hat Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License"); you may not use this file except in compliance with the
+License. You may obtain a copy of the License at
+
+  http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software distributed under the License is distributed on an
+"AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the License for the specific
+language governing permissions and limitations under the License.
+*/
+
+package provider
+
+import (
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+func TestAuthFromEnv(t *testing.T) {
+	env := map[string]string{
+		"OSAC_TOKEN":         "env-token",
+		"OSAC_CLIENT_ID":     "env-client",
+		"OSAC_CLIENT_SECRET": "env-secret",
+		"OSAC_ISSUER":        "https://env.example.com",
+	}
+	tests := []struct {
+		name           string
+		config         OsacProviderModel
+		expectedToken  types.String
+		expectedClient types.String
+		expectedSecret types.String
+	}{
+		{
+			name:           "Nothing configured",
+			expectedToken:  types.StringValue("env-token"),
+			expectedClient: types.StringValue("env-client"),
+			expectedSecret: types.StringValue("env-secret"),
+		},
+		{
+			name: "OAuth2 configured",
+			config: OsacProviderModel{
+				ClientID:     types.StringValue("client"),
+				ClientSecret: types.StringValue("secret"),
+				Issuer:       types.StringValue("https://example.com"),
+			},
+			expectedToken:  types.StringNull(),
+			expectedClient: types.StringValue("client"),
+			expectedSecret: types.StringValue("secret"),
+		},
+		{
+			name: "OAuth2 partly configured",
+			config: OsacProviderModel{
+				ClientID: types.StringValue("client"),
+				Issuer:   types.StringValue("https://example.com"),
+			},
+			expectedToken:  types.StringNull(),
+			expectedClient: types.StringValue("client"),
+			expectedSecret: types.StringValue("env-secret"),
+		},
+		{
+			name: "Token configured",
+			config: OsacProviderModel{
+				Token: types.StringValue("token"),
+			},
+			expectedToken:  types.StringValue("token"),
+			expectedClient: types.StringNull(),
+			expectedSecret: types.StringNull(),
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			for name, value := range env {
+				t.Setenv(name, value)
+			}
+			// The zero values of the attributes that the test doesn't set are null
+			config := test.config
+			authFromEnv(&config)
+			if !config.Token.Equal(test.expectedToken) {
+				t.Errorf("expected token %s, got %s", test.expectedToken, config.Token)
+			}
+			if !config.ClientID.Equal(test.expectedClient) {
+				t.Errorf("expected client_id %s, got %s", test.expectedClient, config.ClientID)
+			}
+			if !config.ClientSecret.Equal(test.expectedSecret) {
+				t.Errorf("expected client_secret %s, got %s", test.expectedSecret, config.ClientSecret)
+			}
+		})
+	}
+}
@@ -15,27 +15,38 @@ package resources
 
 import (
 	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
 	"fmt"
 
+	"github.com/hashicorp/terraform-plugin-framework-timeouts/resource/timeouts"
+	"github.com/hashicorp/terraform-plugin-framework-validators/resourcevalidator"
 	"github.com/hashicorp/terraform-plugin-framework/attr"
 	"github.com/hashicorp/terraform-plugin-framework/diag"
 	"github.com/hashicorp/terraform-plugin-framework/path"
 	"github.com/hashicorp/terraform-plugin-framework/resource"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
-	"github.com/hashicorp/terraform-plugin-framework/resource/schema/mapplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/int64planmodifier"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
 	"github.com/hashicorp/terraform-plugin-framework/types"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
 
 	fulfillmentv1 "github.com/innabox/fulfillment-common/api/fulfillment/v1"
 	sharedv1 "github.com/innabox/fulfillment-common/api/shared/v1"
 
 	"github.com/innabox/terraform-provider-osac/internal/client"
+	"github.com/innabox/terraform-provider-osac/internal/waiter"
 )
 
 // Ensure provider defined types fully satisfy framework interfaces.
 var _ resource.Resource = &ClusterResource{}
 var _ resource.ResourceWithImportState = &ClusterResource{}
+var _ resource.ResourceWithValidateConfig = &ClusterResource{}
+var _ resource.ResourceWithModifyPlan = &ClusterResource{}
+var _ resource.ResourceWithConfigValidators = &ClusterResource{}
 
 func NewClusterResource() resource.Resource {
 	return &ClusterResource{}
@@ -43,20 +54,38 @@ func NewClusterResource() resource.Resource {
 
 // ClusterResource defines the resource implementation.
 type ClusterResource struct {
-	client fulfillmentv1.ClustersClient
+	client          fulfillmentv1.ClustersClient
+	templatesClient fulfillmentv1.ClusterTemplatesClient
+	wait            client.WaitSettings
 }
 
 // ClusterResourceModel describes the resource data model.
 type ClusterResourceModel struct {
-	ID                 types.String `tfsdk:"id"`
-	Name               types.String `tfsdk:"name"`
-	Template           types.String `tfsdk:"template"`
-	TemplateParameters types.Map    `tfsdk:"template_parameters"`
-	NodeSets           types.Map    `tfsdk:"node_sets"`
+	ID                     types.String `tfsdk:"id"`
+	ShortID                types.String `tfsdk:"short_id"`
+	CreatedAt              types.String `tfsdk:"created_at"`
+	Name                   types.String `tfsdk:"name"`
+	NamePrefix             types.String `tfsdk:"name_prefix"`
+	WaitForReady           types.Bool   `tfsdk:"wait_for_ready"`
+	DeletionProtection     types.Bool   `tfsdk:"deletion_protection"`
+	Template               types.String `tfsdk:"template"`
+	TemplateParameters     types.Map    `tfsdk:"template_parameters"`
+	TemplateParametersJSON types.String `tfsdk:"template_parameters_json"`
+	NodeSets               types.Map    `tfsdk:"node_sets"`
+	NodeSetList            types.List   `tfsdk:"node_set"`
 	// Computed status fields
-	State      types.String `tfsdk:"state"`
-	ApiURL     types.String `tfsdk:"api_url"`
-	ConsoleURL types.String `tfsdk:"console_url"`
+	State                       types.String `tfsdk:"state"`
+	ProvisioningDurationSeconds types.Int64  `tfsdk:"provisioning_duration_seconds"`
+	ApiURL                      types.String `tfsdk:"api_url"`
+	ConsoleURL                  types.String `tfsdk:"console_url"`
+	SpecHash                    types.String `tfsdk:"spec_hash"`
+	// Node counts across all node sets
+	TotalRequestedNodes types.Int32    `tfsdk:"total_requested_nodes"`
+	TotalReadyNodes     types.Int32    `tfsdk:"total_ready_nodes"`
+	NodeSetsStatus      types.Map      `tfsdk:"node_sets_status"`
+	Conditions          types.List     `tfsdk:"conditions"`
+	Kubeconfig          types.String   `tfsdk:"kubeconfig"`
+	Timeouts            timeouts.Value `tfsdk:"timeouts"`
 }
 
 func (r *ClusterResource) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
@@ -74,42 +103,126 @@ func (r *ClusterResource) Schema(ctx context.Context, req resource.SchemaRequest
 					stringplanmodifier.UseStateForUnknown(),
 				},
 			},
+			"short_id": schema.StringAttribute{
+				Description: "Short form of the identifier: its first 8 characters, or the whole identifier when " +
+					"it is shorter. It never changes for the same cluster.",
+				Computed: true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"created_at": schema.StringAttribute{
+				Description: "Time when the cluster was created, in RFC 3339 format.",
+				Computed:    true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
 			"name": schema.StringAttribute{
-				Description: "Human-friendly name of the cluster.",
-				Optional:    true,
+				Description: "Human-friendly name of the cluster. When omitted and name_prefix is set, a unique name is " +
+					"generated from the prefix.",
+				Optional: true,
+				Computed: true,
+			},
+			"name_prefix": schema.StringAttribute{
+				Description: "Prefix used to generate a unique name of the form <prefix>-<random suffix> when name " +
+					"is not set. Changing it forces a new cluster with a newly generated name.",
+				Optional: true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
 			},
 			"template": schema.StringAttribute{
-				Description: "Reference to the cluster template ID.",
+				Description: "Reference to the cluster template ID. Changing it forces a new cluster.",
 				Required:    true,
 				PlanModifiers: []planmodifier.String{
 					stringplanmodifier.RequiresReplace(),
 				},
 			},
 			"template_parameters": schema.MapAttribute{
-				Description: "Values of the template parameters as a map of strings.",
+				Description: "Values of the template parameters as a map of strings. Changing them forces a new cluster.",
 				Optional:    true,
 				ElementType: types.StringType,
 				PlanModifiers: []planmodifier.Map{
-					mapplanmodifier.RequiresReplace(),
+					templateParametersRequiresReplace(),
+				},
+			},
+			"template_parameters_json": schema.StringAttribute{
+				Description: "Values of the template parameters as a JSON object, for templates that expect typed values. " +
+					"Strings, booleans and numbers are sent with the matching type. Can't be used with " +
+					"template_parameters. Changing them forces a new cluster, but moving the same values " +
+					"between the two attributes doesn't.",
+				Optional: true,
+				PlanModifiers: []planmodifier.String{
+					templateParametersJSONRequiresReplace(),
 				},
 			},
 			"node_sets": schema.MapNestedAttribute{
-				Description: "Desired node sets of the cluster.",
-				Optional:    true,
-				Computed:    true,
+				Description: "Desired node sets of the cluster. Node sets that the backend adds on its own are " +
+					"ignored once node sets are set.",
+				Optional: true,
+				Computed: true,
+				PlanModifiers: []planmodifier.Map{
+					useStateForUnknownUnlessChanged(path.Root("node_set")),
+				},
 				NestedObject: schema.NestedAttributeObject{
 					Attributes: map[string]schema.Attribute{
 						"host_class": schema.StringAttribute{
-							Description: "Identifier of the class of hosts in this set.",
+							Description: "Identifier of the class of hosts in this set. It can't be changed once the set " +
+								"exists, the plan fails if it is: to move to another class, add a set with a new name " +
+								"and remove the old one.",
+							Required: true,
+						},
+						"size": schema.Int32Attribute{
+							Description: "Number of nodes in the set. Changing it resizes the set in place.",
 							Required:    true,
 						},
+					},
+				},
+			},
+			"node_set": schema.ListNestedAttribute{
+				Description: "Desired node sets of the cluster as a list with explicit names. " +
+					"Alternative to node_sets that is easier to build with for expressions; only one of them can be set.",
+				Optional: true,
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: map[string]schema.Attribute{
+						"name": schema.StringAttribute{
+							Description: "Name of the node set. Must be unique within the cluster.",
+							Required:    true,
+						},
+						"host_class": schema.StringAttribute{
+							Description: "Identifier of the class of hosts in this set. It can't be changed once the set " +
+								"exists, the plan fails if it is: to move to another class, add a set with a new name " +
+								"and remove the old one.",
+							Required: true,
+						},
 						"size": schema.Int32Attribute{
-							Description: "Number of nodes in the set.",
+							Description: "Number of nodes in the set. Changing it resizes the set in place.",
 							Required:    true,
 						},
 					},
 				},
 			},
+			"deletion_protection": schema.BoolAttribute{
+				Description: "Prevent the cluster from being destroyed. While it is true in the state, destroying the " +
+					"cluster, including to replace it, fails. Set it to false and apply first. Defaults to false.",
+				Optional: true,
+			},
+			"wait_for_ready": schema.BoolAttribute{
+				Description: "Wait for the cluster to be ready when it is created or updated, and to disappear when it " +
+					"is destroyed. Defaults to the " +
+					"wait_for_ready setting of the provider, which defaults to true. When false, only the status " +
+					"returned right away is stored.",
+				Optional: true,
+			},
+			"provisioning_duration_seconds": schema.Int64Attribute{
+				Description: "Number of seconds the cluster took to become ready after it was created. Useful to " +
+					"tune timeouts. Null for imported objects.",
+				Computed: true,
+				PlanModifiers: []planmodifier.Int64{
+					int64planmodifier.UseStateForUnknown(),
+				},
+			},
 			"state": schema.StringAttribute{
 				Description: "Current state of the cluster (PROGRESSING, READY, FAILED).",
 				Computed:    true,
@@ -122,6 +235,84 @@ func (r *ClusterResource) Schema(ctx context.Context, req resource.SchemaRequest
 				Description: "URL of the console of the cluster.",
 				Computed:    true,
 			},
+			"spec_hash": schema.StringAttribute{
+				Description: "Deterministic hash of the effective spec (template, template parameters and node sets). " +
+					"Suitable for use in lifecycle.replace_triggered_by.",
+				Computed: true,
+			},
+			"total_requested_nodes": schema.Int32Attribute{
+				Description: "Total number of nodes requested across all node sets of the spec.",
+				Computed:    true,
+			},
+			"total_ready_nodes": schema.Int32Attribute{
+				Description: "Total number of nodes currently reported across all node sets of the status.",
+				Computed:    true,
+			},
+			"kubeconfig": schema.StringAttribute{
+				Description: "Kubeconfig of the cluster. Only available once the cluster is READY, null before. It is " +
+					"sensitive, so it is redacted in the plan output, but it is stored in the state in plain text.",
+				Computed:  true,
+				Sensitive: true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"node_sets_status": schema.MapNestedAttribute{
+				Description: "Progress of each node set, by name: the requested size from the spec and the ready " +
+					"size reported in the status.",
+				Computed: true,
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: map[string]schema.Attribute{
+						"host_class": schema.StringAttribute{
+							Description: "Identifier of the class of hosts in this set.",
+							Computed:    true,
+						},
+						"requested_size": schema.Int32Attribute{
+							Description: "Number of nodes requested in the spec. Null if the set is only in the status.",
+							Computed:    true,
+						},
+						"ready_size": schema.Int32Attribute{
+							Description: "Number of nodes reported in the status. Zero if the set isn't in the status yet.",
+							Computed:    true,
+						},
+					},
+				},
+			},
+			"conditions": schema.ListNestedAttribute{
+				Description: "Conditions reported in the status of the cluster.",
+				Computed:    true,
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: map[string]schema.Attribute{
+						"type": schema.StringAttribute{
+							Description: "Type of the condition, like 'CLUSTER_CONDITION_TYPE_READY'.",
+							Computed:    true,
+						},
+						"status": schema.StringAttribute{
+							Description: "Status of the condition, like 'CONDITION_STATUS_TRUE'.",
+							Computed:    true,
+						},
+						"reason": schema.StringAttribute{
+							Description: "Machine-readable reason of the last transition of the condition.",
+							Computed:    true,
+						},
+						"message": schema.StringAttribute{
+							Description: "Human-readable details about the last transition of the condition.",
+							Computed:    true,
+						},
+						"last_transition_time": schema.StringAttribute{
+							Description: "Time of the last transition of the condition, in RFC 3339 format.",
+							Computed:    true,
+						},
+					},
+				},
+			},
+		},
+		Blocks: map[string]schema.Block{
+			"timeouts": timeouts.Block(ctx, timeouts.Opts{
+				Create: true,
+				Update: true,
+				Delete: true,
+			}),
 		},
 	}
 }
@@ -141,6 +332,8 @@ func (r *ClusterResource) Configure(ctx context.Context, req resource.ConfigureR
 	}
 
 	r.client = providerData.ClustersClient
+	r.templatesClient = providerData.ClusterTemplatesClient
+	r.wait = providerData.Wait
 }
 
 func (r *ClusterResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
@@ -151,26 +344,29 @@ func (r *ClusterResource) Create(ctx context.Context, req resource.CreateRequest
 		return
 	}
 
+	createTimeout, diags := data.Timeouts.Create(ctx, waiter.DefaultCreateTimeout)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// Convert template parameters
+	templateParams, err := buildTemplateParameters(ctx, data.TemplateParameters, data.TemplateParametersJSON)
+	if err != nil {
+		resp.Diagnostics.AddError("Failed to convert template parameters", operationErrorDetail("create", "cluster", data.Name, "", err))
+		return
+	}
+
 	// Build the cluster spec
 	clusterSpec := &fulfillmentv1.ClusterSpec{
-		Template: data.Template.ValueString(),
+		Template:           data.Template.ValueString(),
+		TemplateParameters: templateParams,
 	}
 
 	// Build node sets if provided
-	if !data.NodeSets.IsNull() && !data.NodeSets.IsUnknown() {
-		nodeSetsMap := make(map[string]NodeSetModel)
-		resp.Diagnostics.Append(data.NodeSets.ElementsAs(ctx, &nodeSetsMap, false)...)
-		if resp.Diagnostics.HasError() {
-			return
-		}
-
-		clusterSpec.NodeSets = make(map[string]*fulfillmentv1.ClusterNodeSet)
-		for name, ns := range nodeSetsMap {
-			clusterSpec.NodeSets[name] = &fulfillmentv1.ClusterNodeSet{
-				HostClass: ns.HostClass.ValueString(),
-				Size:      ns.Size.ValueInt32(),
-			}
-		}
+	clusterSpec.NodeSets = buildNodeSets(ctx, &data, &resp.Diagnostics)
+	if resp.Diagnostics.HasError() {
+		return
 	}
 
 	// Build the cluster
@@ -178,8 +374,13 @@ func (r *ClusterResource) Create(ctx context.Context, req resource.CreateRequest
 		Spec: clusterSpec,
 	}
 
+	// Generate the name from the prefix if needed
+	if data.Name.IsUnknown() && !data.NamePrefix.IsNull() {
+		data.Name = types.StringValue(generateName(data.NamePrefix.ValueString()))
+	}
+
 	// Set metadata if name is provided
-	if !data.Name.IsNull() {
+	if !data.Name.IsNull() && !data.Name.IsUnknown() {
 		cluster.Metadata = &sharedv1.Metadata{
 			Name: data.Name.ValueString(),
 		}
@@ -190,35 +391,50 @@ func (r *ClusterResource) Create(ctx context.Context, req resource.CreateRequest
 		Object: cluster,
 	})
 	if err != nil {
-		resp.Diagnostics.AddError("Failed to create cluster", err.Error())
+		resp.Diagnostics.AddError("Failed to create cluster", operationErrorDetail("create", "cluster", data.Name, "", err))
 		return
 	}
 
 	clusterID := createResp.Object.Id
 
-	// Wait for cluster to reach READY state
-	result, err := WaitForReady(ctx, WaitForReadyConfig{
-		PendingStates: []string{
-			fulfillmentv1.ClusterState_CLUSTER_STATE_UNSPECIFIED.String(),
-			fulfillmentv1.ClusterState_CLUSTER_STATE_PROGRESSING.String(),
-		},
-		TargetStates: []string{
-			fulfillmentv1.ClusterState_CLUSTER_STATE_READY.String(),
-		},
-		RefreshFunc: r.clusterStateRefreshFunc(ctx, clusterID),
-		Timeout:     DefaultCreateTimeout,
-	})
-	if err != nil {
-		resp.Diagnostics.AddError(
-			"Error waiting for cluster to be ready",
-			fmt.Sprintf("Cluster %s: %s", clusterID, err.Error()),
-		)
-		return
+	// Wait for the cluster to be ready, unless waiting is disabled
+	finalCluster := createResp.Object
+	data.ProvisioningDurationSeconds = types.Int64Null()
+	if shouldWait(data.WaitForReady, r.wait) {
+		var stats waiter.Stats
+		result, err := waiter.WaitForReady(ctx, waiter.Config{
+			PendingStates: []string{
+				fulfillmentv1.ClusterState_CLUSTER_STATE_UNSPECIFIED.String(),
+				fulfillmentv1.ClusterState_CLUSTER_STATE_PROGRESSING.String(),
+			},
+			TargetStates: []string{
+				fulfillmentv1.ClusterState_CLUSTER_STATE_READY.String(),
+			},
+			RefreshFunc:       r.clusterStateRefreshFunc(ctx, clusterID),
+			Timeout:           createTimeout,
+			FixedPollInterval: r.wait.PollInterval,
+			MinPollInterval:   r.wait.MinPollInterval,
+			BackoffMultiplier: r.wait.BackoffMultiplier,
+			MaxPollInterval:   r.wait.MaxPollInterval,
+			SlowWarningAfter:  r.wait.SlowWarningAfter,
+			Stats:             &stats,
+		})
+		if err != nil {
+			resp.Diagnostics.AddError(
+				"Error waiting for cluster to be ready",
+				operationErrorDetail("create", "cluster", data.Name, clusterID, err),
+			)
+			saveCreatedID(ctx, resp, clusterID)
+			return
+		}
+		finalCluster = result.(*fulfillmentv1.Cluster)
+		data.ProvisioningDurationSeconds = types.Int64Value(int64(stats.Elapsed.Seconds()))
 	}
 
 	// Update state with the final cluster data
-	finalCluster := result.(*fulfillmentv1.Cluster)
 	r.updateModelFromCluster(ctx, &data, finalCluster, &resp.Diagnostics)
+	r.readKubeconfig(ctx, &data, &resp.Diagnostics)
+	data.SpecHash = clusterSpecHash(ctx, &data, &resp.Diagnostics)
 
 	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
 }
@@ -234,20 +450,54 @@ func (r *ClusterResource) Read(ctx context.Context, req resource.ReadRequest, re
 	getResp, err := r.client.Get(ctx, &fulfillmentv1.ClustersGetRequest{
 		Id: data.ID.ValueString(),
 	})
+	if removeIfNotFound(ctx, err, "cluster", data.ID, resp) {
+		return
+	}
 	if err != nil {
-		resp.Diagnostics.AddError("Failed to read cluster", err.Error())
+		resp.Diagnostics.AddError("Failed to read cluster", operationErrorDetail("read", "cluster", data.Name, data.ID.ValueString(), err))
 		return
 	}
 
 	r.updateModelFromCluster(ctx, &data, getResp.Object, &resp.Diagnostics)
+	r.readKubeconfig(ctx, &data, &resp.Diagnostics)
+
+	// Template parameters are normally kept as configured, but after an import they have to be reconstructed from
+	// the spec so that the first plan doesn't propose a replacement.
+	if isFirstReadAfterImport(ctx, req, resp) && getResp.Object.Spec != nil {
+		data.TemplateParameters, data.TemplateParametersJSON = importedTemplateParameters(ctx,
+			getResp.Object.Spec.TemplateParameters, &resp.Diagnostics)
+	}
+	data.SpecHash = clusterSpecHash(ctx, &data, &resp.Diagnostics)
 
 	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
 }
 
 func (r *ClusterResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
-	var data ClusterResourceModel
+	var data, state ClusterResourceModel
 
 	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	updateTimeout, diags := data.Timeouts.Update(ctx, waiter.DefaultUpdateTimeout)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	nodeSets := buildNodeSets(ctx, &data, &resp.Diagnostics)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// Reject changes that the backend can't apply instead of sending a doomed update. A change of the template
+	// replaces the cluster and host classes are already checked by the plan.
+	if templateParametersDiffer(ctx, req.Plan, req.State, &resp.Diagnostics) {
+		checkImmutableAttributes(ctx, req, &resp.Diagnostics, path.Root("template_parameters"),
+			path.Root("template_parameters_json"))
+	}
 	if resp.Diagnostics.HasError() {
 		return
 	}
@@ -257,93 +507,320 @@ func (r *ClusterResource) Update(ctx context.Context, req resource.UpdateRequest
 		Id: data.ID.ValueString(),
 		Spec: &fulfillmentv1.ClusterSpec{
 			Template: data.Template.ValueString(),
+			NodeSets: nodeSets,
 		},
 	}
 
-	// Update node sets if provided
-	if !data.NodeSets.IsNull() && !data.NodeSets.IsUnknown() {
-		nodeSetsMap := make(map[string]NodeSetModel)
-		resp.Diagnostics.Append(data.NodeSets.ElementsAs(ctx, &nodeSetsMap, false)...)
+	if !data.Name.IsNull() && !data.Name.IsUnknown() {
+		cluster.Metadata = &sharedv1.Metadata{
+			Name: data.Name.ValueString(),
+		}
+	}
+
+	// The template can't change, so only the node sets of the spec are updated
+	var specPaths []string
+	if nodeSets != nil && (!data.NodeSets.Equal(state.NodeSets) || !data.NodeSetList.Equal(state.NodeSetList)) {
+		specPaths = append(specPaths, "spec.node_sets")
+	}
+
+	// The node sets that the backend added on its own aren't in the state, so they are read from the server to be
+	// sent back with the configured ones
+	if len(specPaths) > 0 {
+		getResp, err := r.client.Get(ctx, &fulfillmentv1.ClustersGetRequest{
+			Id: data.ID.ValueString(),
+		})
+		if err != nil {
+			resp.Diagnostics.AddError("Failed to read cluster", operationErrorDetail("update", "cluster", data.Name, data.ID.ValueString(), err))
+			return
+		}
+		cluster.Spec.NodeSets = withBackendSets(nodeSets, getResp.Object.GetSpec().GetNodeSets(),
+			buildNodeSets(ctx, &state, &resp.Diagnostics))
 		if resp.Diagnostics.HasError() {
 			return
 		}
+	}
 
-		cluster.Spec.NodeSets = make(map[string]*fulfillmentv1.ClusterNodeSet)
-		for name, ns := range nodeSetsMap {
-			cluster.Spec.NodeSets[name] = &fulfillmentv1.ClusterNodeSet{
-				HostClass: ns.HostClass.ValueString(),
-				Size:      ns.Size.ValueInt32(),
-			}
+	var updated *fulfillmentv1.Cluster
+	if mask := updateMask(false, data.Name, state.Name, specPaths...); mask != nil {
+		updateResp, err := r.client.Update(ctx, &fulfillmentv1.ClustersUpdateRequest{
+			Object:     cluster,
+			UpdateMask: mask,
+		})
+		if err != nil {
+			resp.Diagnostics.AddError("Failed to update cluster", operationErrorDetail("update", "cluster", data.Name, data.ID.ValueString(), err))
+			return
+		}
+		updated = updateResp.Object
+	} else {
+		// Only attributes of the provider changed, so there is nothing to send, but the cluster is read to refresh
+		// the computed attributes
+		getResp, err := r.client.Get(ctx, &fulfillmentv1.ClustersGetRequest{
+			Id: data.ID.ValueString(),
+		})
+		if err != nil {
+			resp.Diagnostics.AddError("Failed to read cluster", operationErrorDetail("read", "cluster", data.Name, data.ID.ValueString(), err))
+			return
 		}
+		updated = getResp.Object
 	}
 
-	if !data.Name.IsNull() {
-		cluster.Metadata = &sharedv1.Metadata{
-			Name: data.Name.ValueString(),
+	clusterID := updated.Id
+
+	// Wait for the cluster to be ready, unless waiting is disabled
+	finalCluster := updated
+	if shouldWait(data.WaitForReady, r.wait) {
+		result, err := waiter.WaitForReady(ctx, waiter.Config{
+			PendingStates: []string{
+				fulfillmentv1.ClusterState_CLUSTER_STATE_UNSPECIFIED.String(),
+				fulfillmentv1.ClusterState_CLUSTER_STATE_PROGRESSING.String(),
+			},
+			TargetStates: []string{
+				fulfillmentv1.ClusterState_CLUSTER_STATE_READY.String(),
+			},
+			RefreshFunc:       r.clusterStateRefreshFunc(ctx, clusterID),
+			Timeout:           updateTimeout,
+			FixedPollInterval: r.wait.PollInterval,
+			MinPollInterval:   r.wait.MinPollInterval,
+			BackoffMultiplier: r.wait.BackoffMultiplier,
+			MaxPollInterval:   r.wait.MaxPollInterval,
+			SlowWarningAfter:  r.wait.SlowWarningAfter,
+		})
+		if err != nil {
+			resp.Diagnostics.AddError(
+				"Error waiting for cluster to be ready after update",
+				operationErrorDetail("update", "cluster", data.Name, clusterID, err),
+			)
+			return
 		}
+		finalCluster = result.(*fulfillmentv1.Cluster)
 	}
 
-	updateResp, err := r.client.Update(ctx, &fulfillmentv1.ClustersUpdateRequest{
-		Object: cluster,
+	// Update state with the final cluster data
+	r.updateModelFromCluster(ctx, &data, finalCluster, &resp.Diagnostics)
+	r.readKubeconfig(ctx, &data, &resp.Diagnostics)
+	data.SpecHash = clusterSpecHash(ctx, &data, &resp.Diagnostics)
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *ClusterResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	var data ClusterResourceModel
+
+	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if checkDeletionProtection(data.DeletionProtection, "cluster", data.Name, data.ID.ValueString(), &resp.Diagnostics) {
+		return
+	}
+
+	deleteTimeout, diags := data.Timeouts.Delete(ctx, waiter.DefaultDeleteTimeout)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	clusterID := data.ID.ValueString()
+	_, err := r.client.Delete(ctx, &fulfillmentv1.ClustersDeleteRequest{
+		Id: clusterID,
 	})
+	if isNotFound(err) {
+		return
+	}
 	if err != nil {
-		resp.Diagnostics.AddError("Failed to update cluster", err.Error())
+		resp.Diagnostics.AddError("Failed to delete cluster", operationErrorDetail("delete", "cluster", data.Name, clusterID, err))
 		return
 	}
 
-	clusterID := updateResp.Object.Id
+	if !shouldWait(data.WaitForReady, r.wait) {
+		return
+	}
 
-	// Wait for cluster to reach READY state
-	result, err := WaitForReady(ctx, WaitForReadyConfig{
-		PendingStates: []string{
-			fulfillmentv1.ClusterState_CLUSTER_STATE_UNSPECIFIED.String(),
-			fulfillmentv1.ClusterState_CLUSTER_STATE_PROGRESSING.String(),
-		},
-		TargetStates: []string{
-			fulfillmentv1.ClusterState_CLUSTER_STATE_READY.String(),
-		},
-		RefreshFunc: r.clusterStateRefreshFunc(ctx, clusterID),
-		Timeout:     DefaultUpdateTimeout,
+	// Wait for the cluster to disappear
+	_, err = waiter.WaitForState(ctx, waiter.Config{
+		PendingStates: deletePendingStates(fulfillmentv1.ClusterState_name,
+			int32(fulfillmentv1.ClusterState_CLUSTER_STATE_FAILED)),
+		TargetStates:      []string{waiter.DeletedState},
+		RefreshFunc:       r.clusterDeleteRefreshFunc(ctx, clusterID),
+		Timeout:           deleteTimeout,
+		FixedPollInterval: r.wait.PollInterval,
+		MinPollInterval:   r.wait.MinPollInterval,
+		BackoffMultiplier: r.wait.BackoffMultiplier,
+		MaxPollInterval:   r.wait.MaxPollInterval,
+		SlowWarningAfter:  r.wait.SlowWarningAfter,
 	})
 	if err != nil {
 		resp.Diagnostics.AddError(
-			"Error waiting for cluster to be ready after update",
-			fmt.Sprintf("Cluster %s: %s", clusterID, err.Error()),
+			"Error waiting for cluster to be deleted",
+			operationErrorDetail("delete", "cluster", data.Name, clusterID, err),
 		)
 		return
 	}
+}
 
-	// Update state with the final cluster data
-	finalCluster := result.(*fulfillmentv1.Cluster)
-	r.updateModelFromCluster(ctx, &data, finalCluster, &resp.Diagnostics)
+// clusterDeleteRefreshFunc returns a StateRefreshFunc used while the cluster is being deleted. It reports the
+// DeletedState pseudo state once the cluster isn't found, and an error including the reason if it fails.
+func (r *ClusterResource) clusterDeleteRefreshFunc(ctx context.Context, clusterID string) waiter.StateRefreshFunc {
+	return waiter.LogTransitions(ctx, "cluster", clusterID, func() (interface{}, string, error) {
+		getResp, err := r.client.Get(ctx, &fulfillmentv1.ClustersGetRequest{Id: clusterID})
+		if isNotFound(err) {
+			return clusterID, waiter.DeletedState, nil
+		}
+		if err != nil {
+			return nil, "", fmt.Errorf("failed to get cluster: %w", err)
+		}
 
-	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+		cluster := getResp.Object
+		if cluster.Status == nil {
+			return cluster, fulfillmentv1.ClusterState_CLUSTER_STATE_UNSPECIFIED.String(), nil
+		}
+
+		state := cluster.Status.State
+		if state == fulfillmentv1.ClusterState_CLUSTER_STATE_FAILED {
+			return nil, state.String(), fmt.Errorf("cluster reached FAILED state while being deleted%s",
+				client.FailureReason(cluster.Status.Conditions))
+		}
+
+		return cluster, state.String(), nil
+	})
 }
 
-func (r *ClusterResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+func (r *ClusterResource) ConfigValidators(ctx context.Context) []resource.ConfigValidator {
+	return []resource.ConfigValidator{
+		resourcevalidator.Conflicting(path.MatchRoot("name"), path.MatchRoot("name_prefix")),
+		resourcevalidator.Conflicting(path.MatchRoot("template_parameters"), path.MatchRoot("template_parameters_json")),
+	}
+}
+
+func (r *ClusterResource) ModifyPlan(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	// Nothing to do when the resource is being destroyed
+	if req.Plan.Raw.IsNull() {
+		return
+	}
+
+	planName(ctx, req, resp)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
 	var data ClusterResourceModel
 
-	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
 	if resp.Diagnostics.HasError() {
 		return
 	}
 
-	_, err := r.client.Delete(ctx, &fulfillmentv1.ClustersDeleteRequest{
-		Id: data.ID.ValueString(),
+	// The host class of an existing node set can't be changed, so reject it in the plan instead of failing the apply
+	if !req.State.Raw.IsNull() {
+		var state ClusterResourceModel
+		resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+		if resp.Diagnostics.HasError() {
+			return
+		}
+		nodeSetsPath := path.Root("node_sets")
+		if !data.NodeSetList.IsNull() {
+			nodeSetsPath = path.Root("node_set")
+		}
+		checkImmutableHostClasses(nodeSetsPath, nodeSetHostClasses(ctx, &data, &resp.Diagnostics),
+			nodeSetHostClasses(ctx, &state, &resp.Diagnostics), &resp.Diagnostics)
+		if resp.Diagnostics.HasError() {
+			return
+		}
+	}
+
+	resp.Diagnostics.Append(resp.Plan.SetAttribute(ctx, path.Root("spec_hash"), clusterSpecHash(ctx, &data, &resp.Diagnostics))...)
+
+	r.checkTemplate(ctx, req, &data, &resp.Diagnostics)
+}
+
+// checkTemplate checks the template parameters of the plan against the parameter definitions of the template, so that
+// mistakes are reported by the plan instead of failing the apply. It is skipped when the template and the parameters
+// don't change, and only warns when the template can't be fetched.
+func (r *ClusterResource) checkTemplate(ctx context.Context, req resource.ModifyPlanRequest, data *ClusterResourceModel, diags *diag.Diagnostics) {
+	if r.templatesClient == nil || data.Template.IsUnknown() || !templateParametersChanged(ctx, req, diags) {
+		return
+	}
+	getResp, err := r.templatesClient.Get(ctx, &fulfillmentv1.ClusterTemplatesGetRequest{
+		Id: data.Template.ValueString(),
 	})
 	if err != nil {
-		resp.Diagnostics.AddError("Failed to delete cluster", err.Error())
+		diags.AddAttributeWarning(
+			path.Root("template"),
+			"Template parameters not checked",
+			fmt.Sprintf("Could not fetch template '%s' to check the parameters: %s", data.Template.ValueString(), err.Error()),
+		)
+		return
+	}
+	checkTemplateParameters(ctx, data.TemplateParameters, data.TemplateParametersJSON, getResp.Object.Parameters, diags)
+}
+
+func (r *ClusterResource) ValidateConfig(ctx context.Context, req resource.ValidateConfigRequest, resp *resource.ValidateConfigResponse) {
+	var data ClusterResourceModel
+
+	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	validateTemplateParametersJSON(ctx, req, resp)
+
+	if !data.NodeSets.IsNull() && !data.NodeSetList.IsNull() {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("node_set"),
+			"Conflicting node set configuration",
+			"Only one of 'node_sets' or 'node_set' can be set.",
+		)
+		return
+	}
+
+	if data.NodeSetList.IsNull() || data.NodeSetList.IsUnknown() {
+		return
+	}
+
+	var nodeSetList []NamedNodeSetModel
+	resp.Diagnostics.Append(data.NodeSetList.ElementsAs(ctx, &nodeSetList, false)...)
+	if resp.Diagnostics.HasError() {
 		return
 	}
+
+	seen := make(map[string]bool)
+	for i, ns := range nodeSetList {
+		if ns.Name.IsNull() || ns.Name.IsUnknown() {
+			continue
+		}
+		name := ns.Name.ValueString()
+		if seen[name] {
+			resp.Diagnostics.AddAttributeError(
+				path.Root("node_set").AtListIndex(i).AtName("name"),
+				"Duplicate node set name",
+				fmt.Sprintf("The node set name %q is used more than once.", name),
+			)
+		}
+		seen[name] = true
+	}
 }
 
 func (r *ClusterResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
-	resource.ImportStatePassthroughID(ctx, path.Root("id"), req, resp)
+	importState(ctx, req, resp, func(name string) (string, error) {
+		filter := client.NameFilter(name)
+		listResp, err := r.client.List(ctx, &fulfillmentv1.ClustersListRequest{
+			Filter: &filter,
+		})
+		if err != nil {
+			return "", err
+		}
+		found, err := client.SelectByName("cluster", name, listResp.Items)
+		if err != nil {
+			return "", err
+		}
+		return found.Id, nil
+	})
 }
 
 // clusterStateRefreshFunc returns a StateRefreshFunc that fetches the cluster and returns its state.
-func (r *ClusterResource) clusterStateRefreshFunc(ctx context.Context, clusterID string) StateRefreshFunc {
-	return func() (interface{}, string, error) {
+func (r *ClusterResource) clusterStateRefreshFunc(ctx context.Context, clusterID string) waiter.StateRefreshFunc {
+	return waiter.LogTransitions(ctx, "cluster", clusterID, func() (interface{}, string, error) {
 		getResp, err := r.client.Get(ctx, &fulfillmentv1.ClustersGetRequest{Id: clusterID})
 		if err != nil {
 			return nil, "", fmt.Errorf("failed to get cluster: %w", err)
@@ -356,27 +833,38 @@ func (r *ClusterResource) clusterStateRefreshFunc(ctx context.Context, clusterID
 
 		state := cluster.Status.State
 		if state == fulfillmentv1.ClusterState_CLUSTER_STATE_FAILED {
-			return nil, state.String(), fmt.Errorf("cluster reached FAILED state")
+			return nil, state.String(), fmt.Errorf("cluster reached FAILED state%s", client.FailureReason(cluster.Status.Conditions))
 		}
 
 		return cluster, state.String(), nil
-	}
+	})
 }
 
 func (r *ClusterResource) updateModelFromCluster(ctx context.Context, model *ClusterResourceModel, cluster *fulfillmentv1.Cluster, diags *diag.Diagnostics) {
 	model.ID = types.StringValue(cluster.Id)
+	model.ShortID = types.StringValue(shortID(cluster.Id))
 
 	if cluster.Metadata != nil {
 		model.Name = types.StringValue(cluster.Metadata.Name)
+	} else if model.Name.IsUnknown() {
+		model.Name = types.StringNull()
 	}
+	model.CreatedAt = client.CreatedAtValue(cluster.Metadata)
 
+	model.TotalRequestedNodes = types.Int32Null()
 	if cluster.Spec != nil {
 		model.Template = types.StringValue(cluster.Spec.Template)
 
+		var totalRequested int32
+		for _, ns := range cluster.Spec.NodeSets {
+			totalRequested += ns.Size
+		}
+		model.TotalRequestedNodes = types.Int32Value(totalRequested)
+
 		// Convert node sets
 		if cluster.Spec.NodeSets != nil {
 			nodeSets := make(map[string]NodeSetModel)
-			for name, ns := range cluster.Spec.NodeSets {
+			for name, ns := range configuredSets(model.NodeSets, cluster.Spec.NodeSets) {
 				nodeSets[name] = NodeSetModel{
 					HostClass: types.StringValue(ns.HostClass),
 					Size:      types.Int32Value(ns.Size),
@@ -397,11 +885,240 @@ func (r *ClusterResource) updateModelFromCluster(ctx context.Context, model *Clu
 		model.State = types.StringValue(cluster.Status.State.String())
 		model.ApiURL = types.StringValue(cluster.Status.ApiUrl)
 		model.ConsoleURL = types.StringValue(cluster.Status.ConsoleUrl)
+
+		var totalReady int32
+		for _, ns := range cluster.Status.NodeSets {
+			totalReady += ns.Size
+		}
+		model.TotalReadyNodes = types.Int32Value(totalReady)
+		model.Conditions = client.ClusterConditionsValue(cluster.Status.Conditions)
 	} else {
 		model.State = types.StringNull()
 		model.ApiURL = types.StringNull()
 		model.ConsoleURL = types.StringNull()
+		model.TotalReadyNodes = types.Int32Null()
+		model.Conditions = types.ListNull(types.ObjectType{AttrTypes: client.ConditionAttrTypes})
+	}
+	model.NodeSetsStatus = nodeSetsStatusValue(cluster)
+}
+
+// readKubeconfig fills the kubeconfig attribute once the cluster is READY. It is only fetched while it isn't known,
+// so that refreshes don't download it again. When it can't be fetched, it stays null with a warning, and the next
+// refresh tries again.
+func (r *ClusterResource) readKubeconfig(ctx context.Context, model *ClusterResourceModel, diags *diag.Diagnostics) {
+	if !model.Kubeconfig.IsNull() && !model.Kubeconfig.IsUnknown() {
+		return
+	}
+	model.Kubeconfig = types.StringNull()
+	if model.State.ValueString() != fulfillmentv1.ClusterState_CLUSTER_STATE_READY.String() {
+		return
+	}
+
+	kubeconfigResp, err := r.client.GetKubeconfig(ctx, &fulfillmentv1.ClustersGetKubeconfigRequest{
+		Id: model.ID.ValueString(),
+	})
+	if status.Code(err) == codes.Unimplemented {
+		return
+	}
+	if err != nil {
+		diags.AddWarning(
+			"Cluster kubeconfig not available",
+			operationErrorDetail("read kubeconfig", "cluster", model.Name, model.ID.ValueString(), err),
+		)
+		return
+	}
+	model.Kubeconfig = types.StringValue(kubeconfigResp.Kubeconfig)
+}
+
+var nodeSetStatusAttrTypes = map[string]attr.Type{
+	"host_class":     types.StringType,
+	"requested_size": types.Int32Type,
+	"ready_size":     types.Int32Type,
+}
+
+// nodeSetsStatusValue returns the value of the node_sets_status attribute, which combines the node sets of the spec
+// and of the status of the cluster by name.
+func nodeSetsStatusValue(cluster *fulfillmentv1.Cluster) types.Map {
+	hostClasses := map[string]string{}
+	requested := map[string]int32{}
+	ready := map[string]int32{}
+	if cluster.Spec != nil {
+		for name, ns := range cluster.Spec.NodeSets {
+			hostClasses[name] = ns.HostClass
+			requested[name] = ns.Size
+		}
+	}
+	if cluster.Status != nil {
+		for name, ns := range cluster.Status.NodeSets {
+			if _, ok := hostClasses[name]; !ok {
+				hostClasses[name] = ns.HostClass
+			}
+			ready[name] = ns.Size
+		}
+	}
+
+	elements := make(map[string]attr.Value, len(hostClasses))
+	for name, hostClass := range hostClasses {
+		requestedSize := types.Int32Null()
+		if size, ok := requested[name]; ok {
+			requestedSize = types.Int32Value(size)
+		}
+		elements[name] = types.ObjectValueMust(nodeSetStatusAttrTypes, map[string]attr.Value{
+			"host_class":     types.StringValue(hostClass),
+			"requested_size": requestedSize,
+			"ready_size":     types.Int32Value(ready[name]),
+		})
+	}
+	return types.MapValueMust(types.ObjectType{AttrTypes: nodeSetStatusAttrTypes}, elements)
+}
+
+// buildNodeSets converts the node sets configured either with the node_sets map or with the node_set list into
+// the node sets of the cluster spec. It returns nil when neither is known.
+func buildNodeSets(ctx context.Context, data *ClusterResourceModel, diags *diag.Diagnostics) map[string]*fulfillmentv1.ClusterNodeSet {
+	if !data.NodeSetList.IsNull() && !data.NodeSetList.IsUnknown() {
+		var nodeSetList []NamedNodeSetModel
+		diags.Append(data.NodeSetList.ElementsAs(ctx, &nodeSetList, false)...)
+		if diags.HasError() {
+			return nil
+		}
+
+		nodeSets := make(map[string]*fulfillmentv1.ClusterNodeSet)
+		for _, ns := range nodeSetList {
+			nodeSets[ns.Name.ValueString()] = &fulfillmentv1.ClusterNodeSet{
+				HostClass: ns.HostClass.ValueString(),
+				Size:      ns.Size.ValueInt32(),
+			}
+		}
+		return nodeSets
+	}
+
+	if !data.NodeSets.IsNull() && !data.NodeSets.IsUnknown() {
+		nodeSetsMap := make(map[string]NodeSetModel)
+		diags.Append(data.NodeSets.ElementsAs(ctx, &nodeSetsMap, false)...)
+		if diags.HasError() {
+			return nil
+		}
+
+		nodeSets := make(map[string]*fulfillmentv1.ClusterNodeSet)
+		for name, ns := range nodeSetsMap {
+			nodeSets[name] = &fulfillmentv1.ClusterNodeSet{
+				HostClass: ns.HostClass.ValueString(),
+				Size:      ns.Size.ValueInt32(),
+			}
+		}
+		return nodeSets
+	}
+
+	return nil
+}
+
+// nodeSetHostClasses returns the host class of each node set of the model, from either node_set or node_sets, indexed
+// by node set name. Node sets whose name or host class isn't known yet are skipped.
+func nodeSetHostClasses(ctx context.Context, data *ClusterResourceModel, diags *diag.Diagnostics) map[string]string {
+	hostClasses := make(map[string]string)
+	if !data.NodeSetList.IsNull() && !data.NodeSetList.IsUnknown() {
+		var nodeSetList []NamedNodeSetModel
+		diags.Append(data.NodeSetList.ElementsAs(ctx, &nodeSetList, false)...)
+		for _, ns := range nodeSetList {
+			if ns.Name.IsUnknown() || ns.HostClass.IsUnknown() {
+				continue
+			}
+			hostClasses[ns.Name.ValueString()] = ns.HostClass.ValueString()
+		}
+	} else if !data.NodeSets.IsNull() && !data.NodeSets.IsUnknown() {
+		nodeSetsMap := make(map[string]NodeSetModel)
+		diags.Append(data.NodeSets.ElementsAs(ctx, &nodeSetsMap, false)...)
+		for name, ns := range nodeSetsMap {
+			if ns.HostClass.IsUnknown() {
+				continue
+			}
+			hostClasses[name] = ns.HostClass.ValueString()
+		}
+	}
+	return hostClasses
+}
+
+// clusterSpecHash returns a SHA-256 hash of the effective spec described by the model: the template, the template
+// parameters and the node sets, from either node_set or node_sets. The template parameters are decoded from either
+// template_parameters or template_parameters_json and normalized, so that whitespace, key order or moving them from
+// one attribute to the other don't change the hash. The spec is serialized as JSON with sorted map keys so the result
+// doesn't depend on map ordering. An unknown value is returned while any input is unknown.
+func clusterSpecHash(ctx context.Context, data *ClusterResourceModel, diags *diag.Diagnostics) types.String {
+	type hashedNodeSet struct {
+		HostClass string `json:"host_class"`
+		Size      int32  `json:"size"`
+	}
+	spec := struct {
+		Template           string                   `json:"template"`
+		TemplateParameters json.RawMessage          `json:"template_parameters"`
+		NodeSets           map[string]hashedNodeSet `json:"node_sets"`
+	}{
+		TemplateParameters: json.RawMessage("{}"),
+		NodeSets:           make(map[string]hashedNodeSet),
+	}
+
+	if data.Template.IsUnknown() || data.TemplateParameters.IsUnknown() || data.TemplateParametersJSON.IsUnknown() {
+		return types.StringUnknown()
+	}
+	for _, value := range data.TemplateParameters.Elements() {
+		if value.IsUnknown() {
+			return types.StringUnknown()
+		}
+	}
+	spec.Template = data.Template.ValueString()
+
+	params, err := buildTemplateParameters(ctx, data.TemplateParameters, data.TemplateParametersJSON)
+	if err != nil {
+		diags.AddError("Failed to compute spec hash", err.Error())
+		return types.StringUnknown()
+	}
+	if normalized := templateParametersJSONValue(params, diags); !normalized.IsNull() {
+		spec.TemplateParameters = json.RawMessage(normalized.ValueString())
+	}
+
+	addNodeSet := func(name types.String, hostClass types.String, size types.Int32) bool {
+		if name.IsUnknown() || hostClass.IsUnknown() || size.IsUnknown() {
+			return false
+		}
+		spec.NodeSets[name.ValueString()] = hashedNodeSet{
+			HostClass: hostClass.ValueString(),
+			Size:      size.ValueInt32(),
+		}
+		return true
+	}
+	switch {
+	case data.NodeSetList.IsUnknown():
+		return types.StringUnknown()
+	case !data.NodeSetList.IsNull():
+		var nodeSetList []NamedNodeSetModel
+		diags.Append(data.NodeSetList.ElementsAs(ctx, &nodeSetList, false)...)
+		for _, ns := range nodeSetList {
+			if !addNodeSet(ns.Name, ns.HostClass, ns.Size) {
+				return types.StringUnknown()
+			}
+		}
+	case data.NodeSets.IsUnknown():
+		return types.StringUnknown()
+	case !data.NodeSets.IsNull():
+		nodeSetsMap := make(map[string]NodeSetModel)
+		diags.Append(data.NodeSets.ElementsAs(ctx, &nodeSetsMap, false)...)
+		for name, ns := range nodeSetsMap {
+			if !addNodeSet(types.StringValue(name), ns.HostClass, ns.Size) {
+				return types.StringUnknown()
+			}
+		}
 	}
+	if diags.HasError() {
+		return types.StringUnknown()
+	}
+
+	encoded, err := json.Marshal(spec)
+	if err != nil {
+		diags.AddError("Failed to compute spec hash", err.Error())
+		return types.StringUnknown()
+	}
+	sum := sha256.Sum256(encoded)
+	return types.StringValue(hex.EncodeToString(sum[:]))
 }
 
 // NodeSetModel represents a node set in Terraform state
@@ -409,3 +1126,10 @@ type NodeSetModel struct {
 	HostClass types.String `tfsdk:"host_class"`
 	Size      types.Int32  `tfsdk:"size"`
 }
+
+// NamedNodeSetModel represents a node set of the node_set list in Terraform state
+type NamedNodeSetModel struct {
+	Name      types.String `tfsdk:"name"`
+	HostClass types.String `tfsdk:"host_class"`
+	Size      types.Int32  `tfsdk:"size"`
+}
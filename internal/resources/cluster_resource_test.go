@@ -0,0 +1,226 @@
+/*
+Copyright (c) 2025 Red Hat Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License"); you may not use this file except in compliance with the
+License. You may obtain a copy of the License at
+
+  http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software distributed under the License is distributed on an
+"AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the License for the specific
+language governing permissions and limitations under the License.
+*/
+
+package resources
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/proto"
+
+	fulfillmentv1 "github.com/innabox/fulfillment-common/api/fulfillment/v1"
+
+	"github.com/innabox/terraform-provider-osac/internal/waiter"
+)
+
+// fakeClusterResult is the result of a call to the Get method of a fakeClustersClient.
+type fakeClusterResult struct {
+	cluster *fulfillmentv1.Cluster
+	err     error
+}
+
+// fakeClustersClient is a clusters client whose Get method returns the given results, one per call, repeating the
+// last one. Other methods aren't implemented.
+type fakeClustersClient struct {
+	fulfillmentv1.ClustersClient
+	results []fakeClusterResult
+	calls   int
+}
+
+func (c *fakeClustersClient) Get(ctx context.Context, in *fulfillmentv1.ClustersGetRequest,
+	opts ...grpc.CallOption) (*fulfillmentv1.ClustersGetResponse, error) {
+	result := c.results[min(c.calls, len(c.results)-1)]
+	c.calls++
+	if result.err != nil {
+		return nil, result.err
+	}
+	return &fulfillmentv1.ClustersGetResponse{Object: result.cluster}, nil
+}
+
+// progressingCluster returns a cluster in the PROGRESSING state.
+func progressingCluster() *fulfillmentv1.Cluster {
+	return &fulfillmentv1.Cluster{
+		Id: "my-cluster",
+		Status: &fulfillmentv1.ClusterStatus{
+			State: fulfillmentv1.ClusterState_CLUSTER_STATE_PROGRESSING,
+		},
+	}
+}
+
+func TestClusterDeleteRefreshFunc(t *testing.T) {
+	tests := []struct {
+		name          string
+		cluster       *fulfillmentv1.Cluster
+		err           error
+		expectedState string
+		expectedError string
+	}{
+		{
+			name:          "Not found is deleted",
+			err:           status.Error(codes.NotFound, "cluster not found"),
+			expectedState: waiter.DeletedState,
+		},
+		{
+			name:          "Still being deleted",
+			cluster:       progressingCluster(),
+			expectedState: "CLUSTER_STATE_PROGRESSING",
+		},
+		{
+			name: "Failed while being deleted",
+			cluster: &fulfillmentv1.Cluster{
+				Id: "my-cluster",
+				Status: &fulfillmentv1.ClusterStatus{
+					State: fulfillmentv1.ClusterState_CLUSTER_STATE_FAILED,
+					Conditions: []*fulfillmentv1.ClusterCondition{
+						{Message: proto.String("finalizer is stuck")},
+					},
+				},
+			},
+			expectedState: "CLUSTER_STATE_FAILED",
+			expectedError: "finalizer is stuck",
+		},
+		{
+			name:          "Other errors",
+			err:           status.Error(codes.Unavailable, "connection refused"),
+			expectedError: "connection refused",
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			r := &ClusterResource{
+				client: &fakeClustersClient{
+					results: []fakeClusterResult{{cluster: test.cluster, err: test.err}},
+				},
+			}
+			_, state, err := r.clusterDeleteRefreshFunc(context.Background(), "my-cluster")()
+			if state != test.expectedState {
+				t.Errorf("expected state %q, got %q", test.expectedState, state)
+			}
+			switch {
+			case test.expectedError == "" && err != nil:
+				t.Errorf("unexpected error: %v", err)
+			case test.expectedError != "" && (err == nil || !strings.Contains(err.Error(), test.expectedError)):
+				t.Errorf("expected an error containing %q, got %v", test.expectedError, err)
+			}
+		})
+	}
+}
+
+func TestClusterDeleteWait(t *testing.T) {
+	fake := &fakeClustersClient{
+		results: []fakeClusterResult{
+			{cluster: progressingCluster()},
+			{cluster: progressingCluster()},
+			{err: status.Error(codes.NotFound, "cluster not found")},
+		},
+	}
+	r := &ClusterResource{client: fake}
+	_, err := waiter.WaitForState(context.Background(), waiter.Config{
+		PendingStates: deletePendingStates(fulfillmentv1.ClusterState_name,
+			int32(fulfillmentv1.ClusterState_CLUSTER_STATE_FAILED)),
+		TargetStates:      []string{waiter.DeletedState},
+		RefreshFunc:       r.clusterDeleteRefreshFunc(context.Background(), "my-cluster"),
+		Timeout:           5 * time.Second,
+		FixedPollInterval: 10 * time.Millisecond,
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if fake.calls != 3 {
+		t.Errorf("expected 3 polls, got %d", fake.calls)
+	}
+}
+
+func TestClusterSpecHashTemplateParameters(t *testing.T) {
+	hash := func(params types.Map, paramsJSON types.String) types.String {
+		t.Helper()
+		var diags diag.Diagnostics
+		data := &ClusterResourceModel{
+			Template:               types.StringValue("ocp_4_17_small"),
+			TemplateParameters:     params,
+			TemplateParametersJSON: paramsJSON,
+			NodeSets:               types.MapNull(types.ObjectType{AttrTypes: hostSetAttrTypes}),
+			NodeSetList:            types.ListNull(types.ObjectType{AttrTypes: map[string]attr.Type{}}),
+		}
+		result := clusterSpecHash(context.Background(), data, &diags)
+		if diags.HasError() {
+			t.Fatalf("unexpected errors: %v", diags)
+		}
+		return result
+	}
+	noMap := types.MapNull(types.StringType)
+	noJSON := types.StringNull()
+	stringMap := types.MapValueMust(types.StringType, map[string]attr.Value{
+		"pull_secret": types.StringValue("secret"),
+		"version":     types.StringValue("4.17"),
+	})
+	expected := hash(stringMap, noJSON)
+
+	tests := []struct {
+		name       string
+		params     types.Map
+		paramsJSON types.String
+		same       bool
+	}{
+		{
+			name:       "Same parameters as JSON",
+			params:     noMap,
+			paramsJSON: types.StringValue(`{"pull_secret":"secret","version":"4.17"}`),
+			same:       true,
+		},
+		{
+			name:   "Other whitespace and key order",
+			params: noMap,
+			paramsJSON: types.StringValue(`{
+				"version": "4.17",
+				"pull_secret": "secret"
+			}`),
+			same: true,
+		},
+		{
+			name:       "Number instead of string",
+			params:     noMap,
+			paramsJSON: types.StringValue(`{"pull_secret":"secret","version":4.17}`),
+		},
+		{
+			name: "Changed value",
+			params: types.MapValueMust(types.StringType, map[string]attr.Value{
+				"pull_secret": types.StringValue("secret"),
+				"version":     types.StringValue("4.18"),
+			}),
+			paramsJSON: noJSON,
+		},
+		{
+			name:       "No parameters",
+			params:     noMap,
+			paramsJSON: noJSON,
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			actual := hash(test.params, test.paramsJSON)
+			if actual.Equal(expected) != test.same {
+				t.Errorf("expected same hash %t, got %s and %s", test.same, expected, actual)
+			}
+		})
+	}
+}
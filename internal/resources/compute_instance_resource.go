@@ -15,15 +15,22 @@ package resources
 
 import (
 	"context"
+	"encoding/json"
 	"fmt"
+	"strconv"
+	"strings"
 
+	"github.com/hashicorp/terraform-plugin-framework-timeouts/resource/timeouts"
+	"github.com/hashicorp/terraform-plugin-framework-validators/resourcevalidator"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
 	"github.com/hashicorp/terraform-plugin-framework/path"
 	"github.com/hashicorp/terraform-plugin-framework/resource"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
-	"github.com/hashicorp/terraform-plugin-framework/resource/schema/mapplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/int64planmodifier"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
 	"github.com/hashicorp/terraform-plugin-framework/types"
+	"google.golang.org/protobuf/proto"
 	"google.golang.org/protobuf/types/known/anypb"
 	"google.golang.org/protobuf/types/known/wrapperspb"
 
@@ -31,11 +38,15 @@ import (
 	sharedv1 "github.com/innabox/fulfillment-common/api/shared/v1"
 
 	"github.com/innabox/terraform-provider-osac/internal/client"
+	"github.com/innabox/terraform-provider-osac/internal/waiter"
 )
 
 // Ensure provider defined types fully satisfy framework interfaces.
 var _ resource.Resource = &ComputeInstanceResource{}
 var _ resource.ResourceWithImportState = &ComputeInstanceResource{}
+var _ resource.ResourceWithModifyPlan = &ComputeInstanceResource{}
+var _ resource.ResourceWithConfigValidators = &ComputeInstanceResource{}
+var _ resource.ResourceWithValidateConfig = &ComputeInstanceResource{}
 
 func NewComputeInstanceResource() resource.Resource {
 	return &ComputeInstanceResource{}
@@ -43,18 +54,28 @@ func NewComputeInstanceResource() resource.Resource {
 
 // ComputeInstanceResource defines the resource implementation.
 type ComputeInstanceResource struct {
-	client fulfillmentv1.ComputeInstancesClient
+	client          fulfillmentv1.ComputeInstancesClient
+	templatesClient fulfillmentv1.ComputeInstanceTemplatesClient
+	wait            client.WaitSettings
 }
 
 // ComputeInstanceResourceModel describes the resource data model.
 type ComputeInstanceResourceModel struct {
-	ID                 types.String `tfsdk:"id"`
-	Name               types.String `tfsdk:"name"`
-	Template           types.String `tfsdk:"template"`
-	TemplateParameters types.Map    `tfsdk:"template_parameters"`
+	ID                     types.String `tfsdk:"id"`
+	ShortID                types.String `tfsdk:"short_id"`
+	CreatedAt              types.String `tfsdk:"created_at"`
+	Name                   types.String `tfsdk:"name"`
+	NamePrefix             types.String `tfsdk:"name_prefix"`
+	WaitForReady           types.Bool   `tfsdk:"wait_for_ready"`
+	Template               types.String `tfsdk:"template"`
+	TemplateParameters     types.Map    `tfsdk:"template_parameters"`
+	TemplateParametersJSON types.String `tfsdk:"template_parameters_json"`
+	WaitForIP              types.Bool   `tfsdk:"wait_for_ip"`
 	// Computed status fields
-	State     types.String `tfsdk:"state"`
-	IPAddress types.String `tfsdk:"ip_address"`
+	State                       types.String   `tfsdk:"state"`
+	ProvisioningDurationSeconds types.Int64    `tfsdk:"provisioning_duration_seconds"`
+	IPAddress                   types.String   `tfsdk:"ip_address"`
+	Timeouts                    timeouts.Value `tfsdk:"timeouts"`
 }
 
 func (r *ComputeInstanceResource) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
@@ -72,23 +93,78 @@ func (r *ComputeInstanceResource) Schema(ctx context.Context, req resource.Schem
 					stringplanmodifier.UseStateForUnknown(),
 				},
 			},
+			"short_id": schema.StringAttribute{
+				Description: "Short form of the identifier: its first 8 characters, or the whole identifier when " +
+					"it is shorter. It never changes for the same compute instance.",
+				Computed: true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"created_at": schema.StringAttribute{
+				Description: "Time when the compute instance was created, in RFC 3339 format.",
+				Computed:    true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
 			"name": schema.StringAttribute{
-				Description: "Human-friendly name of the compute instance.",
-				Optional:    true,
+				Description: "Human-friendly name of the compute instance. When omitted and name_prefix is set, a unique name is " +
+					"generated from the prefix.",
+				Optional: true,
+				Computed: true,
+			},
+			"name_prefix": schema.StringAttribute{
+				Description: "Prefix used to generate a unique name of the form <prefix>-<random suffix> when name " +
+					"is not set. Changing it forces a new compute instance with a newly generated name.",
+				Optional: true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
 			},
 			"template": schema.StringAttribute{
-				Description: "Reference to the compute instance template ID.",
+				Description: "Reference to the compute instance template ID. Changing it forces a new compute instance.",
 				Required:    true,
 				PlanModifiers: []planmodifier.String{
 					stringplanmodifier.RequiresReplace(),
 				},
 			},
 			"template_parameters": schema.MapAttribute{
-				Description: "Values of the template parameters as a map of strings.",
+				Description: "Values of the template parameters as a map of strings. Changing them forces a new compute instance.",
 				Optional:    true,
 				ElementType: types.StringType,
 				PlanModifiers: []planmodifier.Map{
-					mapplanmodifier.RequiresReplace(),
+					templateParametersRequiresReplace(),
+				},
+			},
+			"template_parameters_json": schema.StringAttribute{
+				Description: "Values of the template parameters as a JSON object, for templates that expect typed values. " +
+					"Strings, booleans and numbers are sent with the matching type. Can't be used with " +
+					"template_parameters. Changing them forces a new compute instance, but moving the same values " +
+					"between the two attributes doesn't.",
+				Optional: true,
+				PlanModifiers: []planmodifier.String{
+					templateParametersJSONRequiresReplace(),
+				},
+			},
+			"wait_for_ip": schema.BoolAttribute{
+				Description: "When creating or updating the compute instance, keep waiting after it is ready until it " +
+					"reports an IP address, within the same timeout. Defaults to false.",
+				Optional: true,
+			},
+			"wait_for_ready": schema.BoolAttribute{
+				Description: "Wait for the compute instance to be ready when it is created or updated, and to disappear " +
+					"when it is destroyed. Defaults to the " +
+					"wait_for_ready setting of the provider, which defaults to true. When false, only the status " +
+					"returned right away is stored.",
+				Optional: true,
+			},
+			"provisioning_duration_seconds": schema.Int64Attribute{
+				Description: "Number of seconds the compute instance took to become ready after it was created. Useful to " +
+					"tune timeouts. Null for imported objects.",
+				Computed: true,
+				PlanModifiers: []planmodifier.Int64{
+					int64planmodifier.UseStateForUnknown(),
 				},
 			},
 			"state": schema.StringAttribute{
@@ -100,6 +176,13 @@ func (r *ComputeInstanceResource) Schema(ctx context.Context, req resource.Schem
 				Computed:    true,
 			},
 		},
+		Blocks: map[string]schema.Block{
+			"timeouts": timeouts.Block(ctx, timeouts.Opts{
+				Create: true,
+				Update: true,
+				Delete: true,
+			}),
+		},
 	}
 }
 
@@ -118,6 +201,8 @@ func (r *ComputeInstanceResource) Configure(ctx context.Context, req resource.Co
 	}
 
 	r.client = providerData.ComputeInstancesClient
+	r.templatesClient = providerData.ComputeInstanceTemplatesClient
+	r.wait = providerData.Wait
 }
 
 func (r *ComputeInstanceResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
@@ -128,10 +213,16 @@ func (r *ComputeInstanceResource) Create(ctx context.Context, req resource.Creat
 		return
 	}
 
+	createTimeout, diags := data.Timeouts.Create(ctx, waiter.DefaultCreateTimeout)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
 	// Convert template parameters
-	templateParams, err := convertTemplateParameters(ctx, data.TemplateParameters)
+	templateParams, err := buildTemplateParameters(ctx, data.TemplateParameters, data.TemplateParametersJSON)
 	if err != nil {
-		resp.Diagnostics.AddError("Failed to convert template parameters", err.Error())
+		resp.Diagnostics.AddError("Failed to convert template parameters", operationErrorDetail("create", "compute instance", data.Name, "", err))
 		return
 	}
 
@@ -146,8 +237,13 @@ func (r *ComputeInstanceResource) Create(ctx context.Context, req resource.Creat
 		Spec: spec,
 	}
 
+	// Generate the name from the prefix if needed
+	if data.Name.IsUnknown() && !data.NamePrefix.IsNull() {
+		data.Name = types.StringValue(generateName(data.NamePrefix.ValueString()))
+	}
+
 	// Set metadata if name is provided
-	if !data.Name.IsNull() {
+	if !data.Name.IsNull() && !data.Name.IsUnknown() {
 		instance.Metadata = &sharedv1.Metadata{
 			Name: data.Name.ValueString(),
 		}
@@ -158,34 +254,48 @@ func (r *ComputeInstanceResource) Create(ctx context.Context, req resource.Creat
 		Object: instance,
 	})
 	if err != nil {
-		resp.Diagnostics.AddError("Failed to create compute instance", err.Error())
+		resp.Diagnostics.AddError("Failed to create compute instance", operationErrorDetail("create", "compute instance", data.Name, "", err))
 		return
 	}
 
 	instanceID := createResp.Object.Id
 
-	// Wait for instance to reach READY state
-	result, err := WaitForReady(ctx, WaitForReadyConfig{
-		PendingStates: []string{
-			fulfillmentv1.ComputeInstanceState_COMPUTE_INSTANCE_STATE_UNSPECIFIED.String(),
-			fulfillmentv1.ComputeInstanceState_COMPUTE_INSTANCE_STATE_PROGRESSING.String(),
-		},
-		TargetStates: []string{
-			fulfillmentv1.ComputeInstanceState_COMPUTE_INSTANCE_STATE_READY.String(),
-		},
-		RefreshFunc: r.instanceStateRefreshFunc(ctx, instanceID),
-		Timeout:     DefaultCreateTimeout,
-	})
-	if err != nil {
-		resp.Diagnostics.AddError(
-			"Error waiting for compute instance to be ready",
-			fmt.Sprintf("Instance %s: %s", instanceID, err.Error()),
-		)
-		return
+	// Wait for the compute instance to be ready, unless waiting is disabled
+	finalInstance := createResp.Object
+	data.ProvisioningDurationSeconds = types.Int64Null()
+	if shouldWait(data.WaitForReady, r.wait) {
+		var stats waiter.Stats
+		result, err := waiter.WaitForReady(ctx, waiter.Config{
+			PendingStates: []string{
+				fulfillmentv1.ComputeInstanceState_COMPUTE_INSTANCE_STATE_UNSPECIFIED.String(),
+				fulfillmentv1.ComputeInstanceState_COMPUTE_INSTANCE_STATE_PROGRESSING.String(),
+				instanceStateWaitingForIP,
+			},
+			TargetStates: []string{
+				fulfillmentv1.ComputeInstanceState_COMPUTE_INSTANCE_STATE_READY.String(),
+			},
+			RefreshFunc:       r.instanceStateRefreshFunc(ctx, instanceID, data.WaitForIP.ValueBool()),
+			Timeout:           createTimeout,
+			FixedPollInterval: r.wait.PollInterval,
+			MinPollInterval:   r.wait.MinPollInterval,
+			BackoffMultiplier: r.wait.BackoffMultiplier,
+			MaxPollInterval:   r.wait.MaxPollInterval,
+			SlowWarningAfter:  r.wait.SlowWarningAfter,
+			Stats:             &stats,
+		})
+		if err != nil {
+			resp.Diagnostics.AddError(
+				"Error waiting for compute instance to be ready",
+				operationErrorDetail("create", "compute instance", data.Name, instanceID, err),
+			)
+			saveCreatedID(ctx, resp, instanceID)
+			return
+		}
+		finalInstance = result.(*fulfillmentv1.ComputeInstance)
+		data.ProvisioningDurationSeconds = types.Int64Value(int64(stats.Elapsed.Seconds()))
 	}
 
 	// Update state with the final instance data
-	finalInstance := result.(*fulfillmentv1.ComputeInstance)
 	r.updateModelFromComputeInstance(&data, finalInstance)
 
 	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
@@ -202,28 +312,55 @@ func (r *ComputeInstanceResource) Read(ctx context.Context, req resource.ReadReq
 	getResp, err := r.client.Get(ctx, &fulfillmentv1.ComputeInstancesGetRequest{
 		Id: data.ID.ValueString(),
 	})
+	if removeIfNotFound(ctx, err, "compute instance", data.ID, resp) {
+		return
+	}
 	if err != nil {
-		resp.Diagnostics.AddError("Failed to read compute instance", err.Error())
+		resp.Diagnostics.AddError("Failed to read compute instance", operationErrorDetail("read", "compute instance", data.Name, data.ID.ValueString(), err))
 		return
 	}
 
 	r.updateModelFromComputeInstance(&data, getResp.Object)
 
+	// Template parameters are normally kept as configured, but after an import they have to be reconstructed from
+	// the spec so that the first plan doesn't propose a replacement.
+	if isFirstReadAfterImport(ctx, req, resp) && getResp.Object.Spec != nil {
+		data.TemplateParameters, data.TemplateParametersJSON = importedTemplateParameters(ctx,
+			getResp.Object.Spec.TemplateParameters, &resp.Diagnostics)
+	}
+
 	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
 }
 
 func (r *ComputeInstanceResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
-	var data ComputeInstanceResourceModel
+	var data, state ComputeInstanceResourceModel
 
 	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	updateTimeout, diags := data.Timeouts.Update(ctx, waiter.DefaultUpdateTimeout)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// Reject changes that the backend can't apply instead of sending a doomed update. A change of the template
+	// replaces the compute instance.
+	if templateParametersDiffer(ctx, req.Plan, req.State, &resp.Diagnostics) {
+		checkImmutableAttributes(ctx, req, &resp.Diagnostics, path.Root("template_parameters"),
+			path.Root("template_parameters_json"))
+	}
 	if resp.Diagnostics.HasError() {
 		return
 	}
 
 	// Convert template parameters
-	templateParams, err := convertTemplateParameters(ctx, data.TemplateParameters)
+	templateParams, err := buildTemplateParameters(ctx, data.TemplateParameters, data.TemplateParametersJSON)
 	if err != nil {
-		resp.Diagnostics.AddError("Failed to convert template parameters", err.Error())
+		resp.Diagnostics.AddError("Failed to convert template parameters", operationErrorDetail("update", "compute instance", data.Name, data.ID.ValueString(), err))
 		return
 	}
 
@@ -238,74 +375,233 @@ func (r *ComputeInstanceResource) Update(ctx context.Context, req resource.Updat
 		Spec: spec,
 	}
 
-	if !data.Name.IsNull() {
+	if !data.Name.IsNull() && !data.Name.IsUnknown() {
 		instance.Metadata = &sharedv1.Metadata{
 			Name: data.Name.ValueString(),
 		}
 	}
 
-	updateResp, err := r.client.Update(ctx, &fulfillmentv1.ComputeInstancesUpdateRequest{
-		Object: instance,
+	// The template and its parameters can't change, so the spec isn't updated
+	var updated *fulfillmentv1.ComputeInstance
+	if mask := updateMask(false, data.Name, state.Name); mask != nil {
+		updateResp, err := r.client.Update(ctx, &fulfillmentv1.ComputeInstancesUpdateRequest{
+			Object:     instance,
+			UpdateMask: mask,
+		})
+		if err != nil {
+			resp.Diagnostics.AddError("Failed to update compute instance", operationErrorDetail("update", "compute instance", data.Name, data.ID.ValueString(), err))
+			return
+		}
+		updated = updateResp.Object
+	} else {
+		// Only attributes of the provider changed, so there is nothing to send, but the compute instance is read to
+		// refresh the computed attributes
+		getResp, err := r.client.Get(ctx, &fulfillmentv1.ComputeInstancesGetRequest{
+			Id: data.ID.ValueString(),
+		})
+		if err != nil {
+			resp.Diagnostics.AddError("Failed to read compute instance", operationErrorDetail("read", "compute instance", data.Name, data.ID.ValueString(), err))
+			return
+		}
+		updated = getResp.Object
+	}
+
+	instanceID := updated.Id
+
+	// Wait for the compute instance to be ready, unless waiting is disabled
+	finalInstance := updated
+	if shouldWait(data.WaitForReady, r.wait) {
+		result, err := waiter.WaitForReady(ctx, waiter.Config{
+			PendingStates: []string{
+				fulfillmentv1.ComputeInstanceState_COMPUTE_INSTANCE_STATE_UNSPECIFIED.String(),
+				fulfillmentv1.ComputeInstanceState_COMPUTE_INSTANCE_STATE_PROGRESSING.String(),
+				instanceStateWaitingForIP,
+			},
+			TargetStates: []string{
+				fulfillmentv1.ComputeInstanceState_COMPUTE_INSTANCE_STATE_READY.String(),
+			},
+			RefreshFunc:       r.instanceStateRefreshFunc(ctx, instanceID, data.WaitForIP.ValueBool()),
+			Timeout:           updateTimeout,
+			FixedPollInterval: r.wait.PollInterval,
+			MinPollInterval:   r.wait.MinPollInterval,
+			BackoffMultiplier: r.wait.BackoffMultiplier,
+			MaxPollInterval:   r.wait.MaxPollInterval,
+			SlowWarningAfter:  r.wait.SlowWarningAfter,
+		})
+		if err != nil {
+			resp.Diagnostics.AddError(
+				"Error waiting for compute instance to be ready after update",
+				operationErrorDetail("update", "compute instance", data.Name, instanceID, err),
+			)
+			return
+		}
+		finalInstance = result.(*fulfillmentv1.ComputeInstance)
+	}
+
+	// Update state with the final instance data
+	r.updateModelFromComputeInstance(&data, finalInstance)
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *ComputeInstanceResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	var data ComputeInstanceResourceModel
+
+	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	deleteTimeout, diags := data.Timeouts.Delete(ctx, waiter.DefaultDeleteTimeout)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	instanceID := data.ID.ValueString()
+	_, err := r.client.Delete(ctx, &fulfillmentv1.ComputeInstancesDeleteRequest{
+		Id: instanceID,
 	})
+	if isNotFound(err) {
+		return
+	}
 	if err != nil {
-		resp.Diagnostics.AddError("Failed to update compute instance", err.Error())
+		resp.Diagnostics.AddError("Failed to delete compute instance", operationErrorDetail("delete", "compute instance", data.Name, instanceID, err))
 		return
 	}
 
-	instanceID := updateResp.Object.Id
+	if !shouldWait(data.WaitForReady, r.wait) {
+		return
+	}
 
-	// Wait for instance to reach READY state
-	result, err := WaitForReady(ctx, WaitForReadyConfig{
-		PendingStates: []string{
-			fulfillmentv1.ComputeInstanceState_COMPUTE_INSTANCE_STATE_UNSPECIFIED.String(),
-			fulfillmentv1.ComputeInstanceState_COMPUTE_INSTANCE_STATE_PROGRESSING.String(),
-		},
-		TargetStates: []string{
-			fulfillmentv1.ComputeInstanceState_COMPUTE_INSTANCE_STATE_READY.String(),
-		},
-		RefreshFunc: r.instanceStateRefreshFunc(ctx, instanceID),
-		Timeout:     DefaultUpdateTimeout,
+	// Wait for the compute instance to disappear
+	_, err = waiter.WaitForState(ctx, waiter.Config{
+		PendingStates: deletePendingStates(fulfillmentv1.ComputeInstanceState_name,
+			int32(fulfillmentv1.ComputeInstanceState_COMPUTE_INSTANCE_STATE_FAILED)),
+		TargetStates:      []string{waiter.DeletedState},
+		RefreshFunc:       r.instanceDeleteRefreshFunc(ctx, instanceID),
+		Timeout:           deleteTimeout,
+		FixedPollInterval: r.wait.PollInterval,
+		MinPollInterval:   r.wait.MinPollInterval,
+		BackoffMultiplier: r.wait.BackoffMultiplier,
+		MaxPollInterval:   r.wait.MaxPollInterval,
+		SlowWarningAfter:  r.wait.SlowWarningAfter,
 	})
 	if err != nil {
 		resp.Diagnostics.AddError(
-			"Error waiting for compute instance to be ready after update",
-			fmt.Sprintf("Instance %s: %s", instanceID, err.Error()),
+			"Error waiting for compute instance to be deleted",
+			operationErrorDetail("delete", "compute instance", data.Name, instanceID, err),
 		)
 		return
 	}
+}
 
-	// Update state with the final instance data
-	finalInstance := result.(*fulfillmentv1.ComputeInstance)
-	r.updateModelFromComputeInstance(&data, finalInstance)
+// instanceDeleteRefreshFunc returns a StateRefreshFunc used while the compute instance is being deleted. It reports the
+// DeletedState pseudo state once the compute instance isn't found, and an error including the reason if it fails.
+func (r *ComputeInstanceResource) instanceDeleteRefreshFunc(ctx context.Context, instanceID string) waiter.StateRefreshFunc {
+	return waiter.LogTransitions(ctx, "compute instance", instanceID, func() (interface{}, string, error) {
+		getResp, err := r.client.Get(ctx, &fulfillmentv1.ComputeInstancesGetRequest{Id: instanceID})
+		if isNotFound(err) {
+			return instanceID, waiter.DeletedState, nil
+		}
+		if err != nil {
+			return nil, "", fmt.Errorf("failed to get compute instance: %w", err)
+		}
 
-	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+		instance := getResp.Object
+		if instance.Status == nil {
+			return instance, fulfillmentv1.ComputeInstanceState_COMPUTE_INSTANCE_STATE_UNSPECIFIED.String(), nil
+		}
+
+		state := instance.Status.State
+		if state == fulfillmentv1.ComputeInstanceState_COMPUTE_INSTANCE_STATE_FAILED {
+			return nil, state.String(), fmt.Errorf("compute instance reached FAILED state while being deleted%s",
+				client.FailureReason(instance.Status.Conditions))
+		}
+
+		return instance, state.String(), nil
+	})
 }
 
-func (r *ComputeInstanceResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+func (r *ComputeInstanceResource) ConfigValidators(ctx context.Context) []resource.ConfigValidator {
+	return []resource.ConfigValidator{
+		resourcevalidator.Conflicting(path.MatchRoot("name"), path.MatchRoot("name_prefix")),
+		resourcevalidator.Conflicting(path.MatchRoot("template_parameters"), path.MatchRoot("template_parameters_json")),
+	}
+}
+
+func (r *ComputeInstanceResource) ValidateConfig(ctx context.Context, req resource.ValidateConfigRequest, resp *resource.ValidateConfigResponse) {
+	validateTemplateParametersJSON(ctx, req, resp)
+}
+
+func (r *ComputeInstanceResource) ModifyPlan(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	// Nothing to do when the resource is being destroyed
+	if req.Plan.Raw.IsNull() {
+		return
+	}
+
+	planName(ctx, req, resp)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
 	var data ComputeInstanceResourceModel
 
-	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
 	if resp.Diagnostics.HasError() {
 		return
 	}
 
-	_, err := r.client.Delete(ctx, &fulfillmentv1.ComputeInstancesDeleteRequest{
-		Id: data.ID.ValueString(),
+	r.checkTemplate(ctx, req, &data, &resp.Diagnostics)
+}
+
+// checkTemplate checks the template parameters of the plan against the parameter definitions of the template, so that
+// mistakes are reported by the plan instead of failing the apply. It is skipped when the template and the parameters
+// don't change, and only warns when the template can't be fetched.
+func (r *ComputeInstanceResource) checkTemplate(ctx context.Context, req resource.ModifyPlanRequest, data *ComputeInstanceResourceModel, diags *diag.Diagnostics) {
+	if r.templatesClient == nil || data.Template.IsUnknown() || !templateParametersChanged(ctx, req, diags) {
+		return
+	}
+	getResp, err := r.templatesClient.Get(ctx, &fulfillmentv1.ComputeInstanceTemplatesGetRequest{
+		Id: data.Template.ValueString(),
 	})
 	if err != nil {
-		resp.Diagnostics.AddError("Failed to delete compute instance", err.Error())
+		diags.AddAttributeWarning(
+			path.Root("template"),
+			"Template parameters not checked",
+			fmt.Sprintf("Could not fetch template '%s' to check the parameters: %s", data.Template.ValueString(), err.Error()),
+		)
 		return
 	}
+	checkTemplateParameters(ctx, data.TemplateParameters, data.TemplateParametersJSON, getResp.Object.Parameters, diags)
 }
 
 func (r *ComputeInstanceResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
-	resource.ImportStatePassthroughID(ctx, path.Root("id"), req, resp)
+	importState(ctx, req, resp, func(name string) (string, error) {
+		filter := client.NameFilter(name)
+		listResp, err := r.client.List(ctx, &fulfillmentv1.ComputeInstancesListRequest{
+			Filter: &filter,
+		})
+		if err != nil {
+			return "", err
+		}
+		found, err := client.SelectByName("compute instance", name, listResp.Items)
+		if err != nil {
+			return "", err
+		}
+		return found.Id, nil
+	})
 }
 
+// instanceStateWaitingForIP is the pending state reported by the refresh function when the instance is ready but
+// doesn't have an IP address yet and the caller asked to wait for it.
+const instanceStateWaitingForIP = "WAITING_FOR_IP_ADDRESS"
+
 // instanceStateRefreshFunc returns a StateRefreshFunc that fetches the instance and returns its state.
-// This follows the AWS provider pattern for polling resource status.
-func (r *ComputeInstanceResource) instanceStateRefreshFunc(ctx context.Context, instanceID string) StateRefreshFunc {
-	return func() (interface{}, string, error) {
+// This follows the AWS provider pattern for polling resource status. When waitForIP is true a ready instance without
+// an IP address is reported as still pending.
+func (r *ComputeInstanceResource) instanceStateRefreshFunc(ctx context.Context, instanceID string, waitForIP bool) waiter.StateRefreshFunc {
+	return waiter.LogTransitions(ctx, "compute instance", instanceID, func() (interface{}, string, error) {
 		getResp, err := r.client.Get(ctx, &fulfillmentv1.ComputeInstancesGetRequest{Id: instanceID})
 		if err != nil {
 			return nil, "", fmt.Errorf("failed to get compute instance: %w", err)
@@ -321,11 +617,16 @@ func (r *ComputeInstanceResource) instanceStateRefreshFunc(ctx context.Context,
 
 		// If the instance has failed, return an error to stop polling
 		if state == fulfillmentv1.ComputeInstanceState_COMPUTE_INSTANCE_STATE_FAILED {
-			return nil, state.String(), fmt.Errorf("compute instance reached FAILED state")
+			return nil, state.String(), fmt.Errorf("compute instance reached FAILED state%s", client.FailureReason(instance.Status.Conditions))
+		}
+
+		if waitForIP && state == fulfillmentv1.ComputeInstanceState_COMPUTE_INSTANCE_STATE_READY &&
+			instance.Status.IpAddress == "" {
+			return instance, instanceStateWaitingForIP, nil
 		}
 
 		return instance, state.String(), nil
-	}
+	})
 }
 
 // convertTemplateParameters converts a Terraform map of strings to a protobuf map of Any values.
@@ -354,12 +655,175 @@ func convertTemplateParameters(ctx context.Context, tfMap types.Map) (map[string
 	return result, nil
 }
 
+// buildTemplateParameters converts the template parameters of the configuration, given either as a map of strings or
+// as a JSON object, to a protobuf map of Any values.
+func buildTemplateParameters(ctx context.Context, tfMap types.Map, tfJSON types.String) (map[string]*anypb.Any, error) {
+	if !tfJSON.IsNull() && !tfJSON.IsUnknown() {
+		return convertTemplateParametersJSON(tfJSON.ValueString())
+	}
+	return convertTemplateParameters(ctx, tfMap)
+}
+
+// convertTemplateParametersJSON converts a JSON object to a protobuf map of Any values, wrapping each value in the
+// wrapper type that matches its JSON type. Numbers without a fractional part become Int64Value, other numbers
+// DoubleValue.
+func convertTemplateParametersJSON(text string) (map[string]*anypb.Any, error) {
+	decoder := json.NewDecoder(strings.NewReader(text))
+	decoder.UseNumber()
+	var values map[string]interface{}
+	if err := decoder.Decode(&values); err != nil {
+		return nil, fmt.Errorf("template parameters must be a JSON object: %w", err)
+	}
+
+	result := make(map[string]*anypb.Any)
+	for key, value := range values {
+		var wrapped proto.Message
+		switch typed := value.(type) {
+		case string:
+			wrapped = wrapperspb.String(typed)
+		case bool:
+			wrapped = wrapperspb.Bool(typed)
+		case json.Number:
+			if integer, err := typed.Int64(); err == nil {
+				wrapped = wrapperspb.Int64(integer)
+			} else if float, err := typed.Float64(); err == nil {
+				wrapped = wrapperspb.Double(float)
+			} else {
+				return nil, fmt.Errorf("parameter %q has invalid number %s: %w", key, typed, err)
+			}
+		default:
+			return nil, fmt.Errorf("parameter %q must be a string, a boolean or a number, but it is %T", key, value)
+		}
+		anyValue, err := anypb.New(wrapped)
+		if err != nil {
+			return nil, fmt.Errorf("could not convert parameter %q: %w", key, err)
+		}
+		result[key] = anyValue
+	}
+
+	return result, nil
+}
+
+// validateTemplateParametersJSON checks that the template_parameters_json attribute, when known, holds values that
+// can be sent as template parameters.
+func validateTemplateParametersJSON(ctx context.Context, req resource.ValidateConfigRequest, resp *resource.ValidateConfigResponse) {
+	var value types.String
+	resp.Diagnostics.Append(req.Config.GetAttribute(ctx, path.Root("template_parameters_json"), &value)...)
+	if resp.Diagnostics.HasError() || value.IsNull() || value.IsUnknown() {
+		return
+	}
+	if _, err := convertTemplateParametersJSON(value.ValueString()); err != nil {
+		resp.Diagnostics.AddAttributeError(path.Root("template_parameters_json"), "Invalid template parameters", err.Error())
+	}
+}
+
+// importedTemplateParameters converts the template parameters of a spec back to the attribute that can hold them: the
+// map of strings when all the values are strings, the JSON object otherwise. The other attribute is null.
+func importedTemplateParameters(ctx context.Context, params map[string]*anypb.Any, diags *diag.Diagnostics) (types.Map, types.String) {
+	for _, value := range params {
+		if !value.MessageIs(&wrapperspb.StringValue{}) {
+			return types.MapNull(types.StringType), templateParametersJSONValue(params, diags)
+		}
+	}
+	return templateParametersValue(ctx, params, diags), types.StringNull()
+}
+
+// templateParametersJSONValue converts the template parameters of a spec back to a JSON object, using the JSON type
+// that convertTemplateParametersJSON maps to the same wrapper type. It returns a null string when there are no
+// parameters.
+func templateParametersJSONValue(params map[string]*anypb.Any, diags *diag.Diagnostics) types.String {
+	if len(params) == 0 {
+		return types.StringNull()
+	}
+
+	values := make(map[string]any)
+	for key, value := range params {
+		message, err := value.UnmarshalNew()
+		if err != nil {
+			diags.AddError("Failed to read template parameters", fmt.Sprintf("Could not decode parameter %q: %s", key, err.Error()))
+			return types.StringNull()
+		}
+		switch typed := message.(type) {
+		case *wrapperspb.StringValue:
+			values[key] = typed.GetValue()
+		case *wrapperspb.BoolValue:
+			values[key] = typed.GetValue()
+		case *wrapperspb.Int32Value:
+			values[key] = json.Number(strconv.FormatInt(int64(typed.GetValue()), 10))
+		case *wrapperspb.Int64Value:
+			values[key] = json.Number(strconv.FormatInt(typed.GetValue(), 10))
+		case *wrapperspb.DoubleValue:
+			// Keep a fractional part so that the number is read back as a double and not as an integer
+			text := strconv.FormatFloat(typed.GetValue(), 'g', -1, 64)
+			if !strings.ContainsAny(text, ".eEn") {
+				text += ".0"
+			}
+			values[key] = json.Number(text)
+		default:
+			diags.AddWarning(
+				"Unsupported template parameter type",
+				fmt.Sprintf("Parameter %q has type %s and is not included in template_parameters_json.", key, value.GetTypeUrl()),
+			)
+		}
+	}
+
+	text, err := json.Marshal(values)
+	if err != nil {
+		diags.AddError("Failed to read template parameters", err.Error())
+		return types.StringNull()
+	}
+	return types.StringValue(string(text))
+}
+
+// templateParametersValue converts the template parameters of a spec back to a Terraform map of strings. Values
+// wrapping strings are used as is and other wrapper types are formatted as text. It returns a null map when there
+// are no parameters.
+func templateParametersValue(ctx context.Context, params map[string]*anypb.Any, diags *diag.Diagnostics) types.Map {
+	if len(params) == 0 {
+		return types.MapNull(types.StringType)
+	}
+
+	stringMap := make(map[string]string)
+	for key, value := range params {
+		message, err := value.UnmarshalNew()
+		if err != nil {
+			diags.AddError("Failed to read template parameters", fmt.Sprintf("Could not decode parameter %q: %s", key, err.Error()))
+			return types.MapNull(types.StringType)
+		}
+		switch typed := message.(type) {
+		case *wrapperspb.StringValue:
+			stringMap[key] = typed.GetValue()
+		case *wrapperspb.BoolValue:
+			stringMap[key] = strconv.FormatBool(typed.GetValue())
+		case *wrapperspb.Int32Value:
+			stringMap[key] = strconv.FormatInt(int64(typed.GetValue()), 10)
+		case *wrapperspb.Int64Value:
+			stringMap[key] = strconv.FormatInt(typed.GetValue(), 10)
+		case *wrapperspb.DoubleValue:
+			stringMap[key] = strconv.FormatFloat(typed.GetValue(), 'g', -1, 64)
+		default:
+			diags.AddWarning(
+				"Unsupported template parameter type",
+				fmt.Sprintf("Parameter %q has type %s and is not included in template_parameters.", key, value.GetTypeUrl()),
+			)
+		}
+	}
+
+	result, d := types.MapValueFrom(ctx, types.StringType, stringMap)
+	diags.Append(d...)
+	return result
+}
+
 func (r *ComputeInstanceResource) updateModelFromComputeInstance(model *ComputeInstanceResourceModel, instance *fulfillmentv1.ComputeInstance) {
 	model.ID = types.StringValue(instance.Id)
+	model.ShortID = types.StringValue(shortID(instance.Id))
 
 	if instance.Metadata != nil {
 		model.Name = types.StringValue(instance.Metadata.Name)
+	} else if model.Name.IsUnknown() {
+		model.Name = types.StringNull()
 	}
+	model.CreatedAt = client.CreatedAtValue(instance.Metadata)
 
 	if instance.Spec != nil {
 		model.Template = types.StringValue(instance.Spec.Template)
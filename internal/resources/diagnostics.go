@@ -0,0 +1,65 @@
+/*
+Copyright (c) 2025 Red Hat Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License"); you may not use this file except in compliance with the
+License. You may obtain a copy of the License at
+
+  http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software distributed under the License is distributed on an
+"AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the License for the specific
+language governing permissions and limitations under the License.
+*/
+
+package resources
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// operationErrorDetail returns the detail of an error diagnostic about an operation on an object. It names the
+// operation and, when they are known, the name and identifier of the object, so that each error of a large apply
+// describes itself.
+func operationErrorDetail(operation, kind string, name types.String, id string, err error) string {
+	var refs []string
+	if !name.IsNull() && !name.IsUnknown() && name.ValueString() != "" {
+		refs = append(refs, fmt.Sprintf("name: %s", name.ValueString()))
+	}
+	if id != "" {
+		refs = append(refs, fmt.Sprintf("id: %s", id))
+	}
+	subject := kind
+	if len(refs) > 0 {
+		subject = fmt.Sprintf("%s (%s)", kind, strings.Join(refs, ", "))
+	}
+	return fmt.Sprintf("Operation %s on %s failed: %s", operation, subject, err.Error())
+}
+
+// saveCreatedID saves the identifier of an object that was created but didn't become ready. Terraform then keeps
+// the resource in the state as tainted, so the object is tracked and replaced by the next apply instead of being
+// leaked.
+func saveCreatedID(ctx context.Context, resp *resource.CreateResponse, id string) {
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("id"), id)...)
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("short_id"), shortID(id))...)
+}
+
+// checkDeletionProtection reports an error, and returns true, when the deletion protection of the object is enabled in
+// the state. It has to be disabled and applied before the object can be destroyed.
+func checkDeletionProtection(deletionProtection types.Bool, kind string, name types.String, id string, diags *diag.Diagnostics) bool {
+	if !deletionProtection.ValueBool() {
+		return false
+	}
+	diags.AddError(
+		fmt.Sprintf("Cannot delete protected %s", kind),
+		operationErrorDetail("delete", kind, name, id, fmt.Errorf("deletion_protection is enabled. Set it to "+
+			"false and apply before destroying the %s", kind)),
+	)
+	return true
+}
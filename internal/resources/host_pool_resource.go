@@ -17,11 +17,15 @@ import (
 	"context"
 	"fmt"
 
+	"github.com/hashicorp/terraform-plugin-framework-timeouts/resource/timeouts"
+	"github.com/hashicorp/terraform-plugin-framework-validators/resourcevalidator"
 	"github.com/hashicorp/terraform-plugin-framework/attr"
 	"github.com/hashicorp/terraform-plugin-framework/diag"
 	"github.com/hashicorp/terraform-plugin-framework/path"
 	"github.com/hashicorp/terraform-plugin-framework/resource"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/int64planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/mapplanmodifier"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
 	"github.com/hashicorp/terraform-plugin-framework/types"
@@ -30,11 +34,14 @@ import (
 	sharedv1 "github.com/innabox/fulfillment-common/api/shared/v1"
 
 	"github.com/innabox/terraform-provider-osac/internal/client"
+	"github.com/innabox/terraform-provider-osac/internal/waiter"
 )
 
 // Ensure provider defined types fully satisfy framework interfaces.
 var _ resource.Resource = &HostPoolResource{}
 var _ resource.ResourceWithImportState = &HostPoolResource{}
+var _ resource.ResourceWithModifyPlan = &HostPoolResource{}
+var _ resource.ResourceWithConfigValidators = &HostPoolResource{}
 
 func NewHostPoolResource() resource.Resource {
 	return &HostPoolResource{}
@@ -42,17 +49,29 @@ func NewHostPoolResource() resource.Resource {
 
 // HostPoolResource defines the resource implementation.
 type HostPoolResource struct {
-	client fulfillmentv1.HostPoolsClient
+	client      fulfillmentv1.HostPoolsClient
+	hostsClient fulfillmentv1.HostsClient
+	wait        client.WaitSettings
 }
 
 // HostPoolResourceModel describes the resource data model.
 type HostPoolResourceModel struct {
-	ID       types.String `tfsdk:"id"`
-	Name     types.String `tfsdk:"name"`
-	HostSets types.Map    `tfsdk:"host_sets"`
+	ID                 types.String `tfsdk:"id"`
+	ShortID            types.String `tfsdk:"short_id"`
+	CreatedAt          types.String `tfsdk:"created_at"`
+	Name               types.String `tfsdk:"name"`
+	NamePrefix         types.String `tfsdk:"name_prefix"`
+	WaitForReady       types.Bool   `tfsdk:"wait_for_ready"`
+	DeletionProtection types.Bool   `tfsdk:"deletion_protection"`
+	HostSets           types.Map    `tfsdk:"host_sets"`
+	ReadHostStates     types.Bool   `tfsdk:"read_host_states"`
 	// Computed status fields
-	State types.String `tfsdk:"state"`
-	Hosts types.List   `tfsdk:"hosts"`
+	State                       types.String   `tfsdk:"state"`
+	ProvisioningDurationSeconds types.Int64    `tfsdk:"provisioning_duration_seconds"`
+	Hosts                       types.List     `tfsdk:"hosts"`
+	ReadyHosts                  types.List     `tfsdk:"ready_hosts"`
+	FailedHosts                 types.List     `tfsdk:"failed_hosts"`
+	Timeouts                    timeouts.Value `tfsdk:"timeouts"`
 }
 
 // HostSetModel represents a host set in Terraform state
@@ -61,6 +80,11 @@ type HostSetModel struct {
 	Size      types.Int32  `tfsdk:"size"`
 }
 
+var hostSetAttrTypes = map[string]attr.Type{
+	"host_class": types.StringType,
+	"size":       types.Int32Type,
+}
+
 func (r *HostPoolResource) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
 	resp.TypeName = req.ProviderTypeName + "_host_pool"
 }
@@ -76,19 +100,50 @@ func (r *HostPoolResource) Schema(ctx context.Context, req resource.SchemaReques
 					stringplanmodifier.UseStateForUnknown(),
 				},
 			},
+			"short_id": schema.StringAttribute{
+				Description: "Short form of the identifier: its first 8 characters, or the whole identifier when " +
+					"it is shorter. It never changes for the same host pool.",
+				Computed: true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"created_at": schema.StringAttribute{
+				Description: "Time when the host pool was created, in RFC 3339 format.",
+				Computed:    true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
 			"name": schema.StringAttribute{
-				Description: "Human-friendly name of the host pool.",
-				Optional:    true,
+				Description: "Human-friendly name of the host pool. When omitted and name_prefix is set, a unique name is " +
+					"generated from the prefix.",
+				Optional: true,
+				Computed: true,
+			},
+			"name_prefix": schema.StringAttribute{
+				Description: "Prefix used to generate a unique name of the form <prefix>-<random suffix> when name " +
+					"is not set. Changing it forces a new host pool with a newly generated name.",
+				Optional: true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
 			},
 			"host_sets": schema.MapNestedAttribute{
-				Description: "Desired host sets of the host pool.",
-				Optional:    true,
-				Computed:    true,
+				Description: "Desired host sets of the host pool. When omitted, the host sets are read from the backend. " +
+					"Host sets that the backend adds on its own are ignored once host sets are set.",
+				Optional: true,
+				Computed: true,
+				PlanModifiers: []planmodifier.Map{
+					mapplanmodifier.UseStateForUnknown(),
+				},
 				NestedObject: schema.NestedAttributeObject{
 					Attributes: map[string]schema.Attribute{
 						"host_class": schema.StringAttribute{
-							Description: "Identifier of the class of hosts in this set.",
-							Required:    true,
+							Description: "Identifier of the class of hosts in this set. It can't be changed once the set " +
+								"exists, the plan fails if it is: to move to another class, add a set with a new name " +
+								"and remove the old one.",
+							Required: true,
 						},
 						"size": schema.Int32Attribute{
 							Description: "Number of hosts in the set.",
@@ -97,6 +152,26 @@ func (r *HostPoolResource) Schema(ctx context.Context, req resource.SchemaReques
 					},
 				},
 			},
+			"deletion_protection": schema.BoolAttribute{
+				Description: "Prevent the host pool from being destroyed. While it is true in the state, destroying the " +
+					"host pool, including to replace it, fails. Set it to false and apply first. Defaults to false.",
+				Optional: true,
+			},
+			"wait_for_ready": schema.BoolAttribute{
+				Description: "Wait for the host pool to be ready when it is created or updated, and to disappear when it " +
+					"is destroyed. Defaults to the " +
+					"wait_for_ready setting of the provider, which defaults to true. When false, only the status " +
+					"returned right away is stored.",
+				Optional: true,
+			},
+			"provisioning_duration_seconds": schema.Int64Attribute{
+				Description: "Number of seconds the host pool took to become ready after it was created. Useful to " +
+					"tune timeouts. Null for imported objects.",
+				Computed: true,
+				PlanModifiers: []planmodifier.Int64{
+					int64planmodifier.UseStateForUnknown(),
+				},
+			},
 			"state": schema.StringAttribute{
 				Description: "Current state of the host pool (PROGRESSING, READY, FAILED).",
 				Computed:    true,
@@ -106,6 +181,28 @@ func (r *HostPoolResource) Schema(ctx context.Context, req resource.SchemaReques
 				Computed:    true,
 				ElementType: types.StringType,
 			},
+			"read_host_states": schema.BoolAttribute{
+				Description: "Read the state of each host of the pool to populate ready_hosts and failed_hosts. " +
+					"This issues one additional call per host, so it is disabled by default.",
+				Optional: true,
+			},
+			"ready_hosts": schema.ListAttribute{
+				Description: "IDs of the hosts of the pool that are READY. Only populated when read_host_states is true.",
+				Computed:    true,
+				ElementType: types.StringType,
+			},
+			"failed_hosts": schema.ListAttribute{
+				Description: "IDs of the hosts of the pool that are FAILED. Only populated when read_host_states is true.",
+				Computed:    true,
+				ElementType: types.StringType,
+			},
+		},
+		Blocks: map[string]schema.Block{
+			"timeouts": timeouts.Block(ctx, timeouts.Opts{
+				Create: true,
+				Update: true,
+				Delete: true,
+			}),
 		},
 	}
 }
@@ -125,6 +222,8 @@ func (r *HostPoolResource) Configure(ctx context.Context, req resource.Configure
 	}
 
 	r.client = providerData.HostPoolsClient
+	r.hostsClient = providerData.HostsClient
+	r.wait = providerData.Wait
 }
 
 func (r *HostPoolResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
@@ -135,6 +234,12 @@ func (r *HostPoolResource) Create(ctx context.Context, req resource.CreateReques
 		return
 	}
 
+	createTimeout, diags := data.Timeouts.Create(ctx, waiter.DefaultCreateTimeout)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
 	// Build the host pool spec
 	spec := &fulfillmentv1.HostPoolSpec{}
 
@@ -160,8 +265,13 @@ func (r *HostPoolResource) Create(ctx context.Context, req resource.CreateReques
 		Spec: spec,
 	}
 
+	// Generate the name from the prefix if needed
+	if data.Name.IsUnknown() && !data.NamePrefix.IsNull() {
+		data.Name = types.StringValue(generateName(data.NamePrefix.ValueString()))
+	}
+
 	// Set metadata if name is provided
-	if !data.Name.IsNull() {
+	if !data.Name.IsNull() && !data.Name.IsUnknown() {
 		hostPool.Metadata = &sharedv1.Metadata{
 			Name: data.Name.ValueString(),
 		}
@@ -172,36 +282,50 @@ func (r *HostPoolResource) Create(ctx context.Context, req resource.CreateReques
 		Object: hostPool,
 	})
 	if err != nil {
-		resp.Diagnostics.AddError("Failed to create host pool", err.Error())
+		resp.Diagnostics.AddError("Failed to create host pool", operationErrorDetail("create", "host pool", data.Name, "", err))
 		return
 	}
 
 	hostPoolID := createResp.Object.Id
 
-	// Wait for host pool to reach READY state
-	result, err := WaitForReady(ctx, WaitForReadyConfig{
-		PendingStates: []string{
-			fulfillmentv1.HostPoolState_HOST_POOL_STATE_UNSPECIFIED.String(),
-			fulfillmentv1.HostPoolState_HOST_POOL_STATE_PROGRESSING.String(),
-		},
-		TargetStates: []string{
-			fulfillmentv1.HostPoolState_HOST_POOL_STATE_READY.String(),
-		},
-		RefreshFunc: r.hostPoolStateRefreshFunc(ctx, hostPoolID),
-		Timeout:     DefaultCreateTimeout,
-	})
-	if err != nil {
-		resp.Diagnostics.AddError(
-			"Error waiting for host pool to be ready",
-			fmt.Sprintf("Host pool %s: %s", hostPoolID, err.Error()),
-		)
-		return
+	// Wait for the host pool to be ready, unless waiting is disabled
+	finalHostPool := createResp.Object
+	data.ProvisioningDurationSeconds = types.Int64Null()
+	if shouldWait(data.WaitForReady, r.wait) {
+		var stats waiter.Stats
+		result, err := waiter.WaitForReady(ctx, waiter.Config{
+			PendingStates: []string{
+				fulfillmentv1.HostPoolState_HOST_POOL_STATE_UNSPECIFIED.String(),
+				fulfillmentv1.HostPoolState_HOST_POOL_STATE_PROGRESSING.String(),
+			},
+			TargetStates: []string{
+				fulfillmentv1.HostPoolState_HOST_POOL_STATE_READY.String(),
+			},
+			RefreshFunc:       r.hostPoolStateRefreshFunc(ctx, hostPoolID),
+			Timeout:           createTimeout,
+			FixedPollInterval: r.wait.PollInterval,
+			MinPollInterval:   r.wait.MinPollInterval,
+			BackoffMultiplier: r.wait.BackoffMultiplier,
+			MaxPollInterval:   r.wait.MaxPollInterval,
+			SlowWarningAfter:  r.wait.SlowWarningAfter,
+			Stats:             &stats,
+		})
+		if err != nil {
+			resp.Diagnostics.AddError(
+				"Error waiting for host pool to be ready",
+				operationErrorDetail("create", "host pool", data.Name, hostPoolID, err),
+			)
+			saveCreatedID(ctx, resp, hostPoolID)
+			return
+		}
+		finalHostPool = result.(*fulfillmentv1.HostPool)
+		data.ProvisioningDurationSeconds = types.Int64Value(int64(stats.Elapsed.Seconds()))
 	}
 
 	// Update state with the final host pool data
-	finalHostPool := result.(*fulfillmentv1.HostPool)
 	r.updateModelFromHostPool(ctx, &data, finalHostPool, &resp.Diagnostics)
 
+	resp.Diagnostics.Append(recordConfiguredName(ctx, req.Config, resp.Private)...)
 	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
 }
 
@@ -216,8 +340,11 @@ func (r *HostPoolResource) Read(ctx context.Context, req resource.ReadRequest, r
 	getResp, err := r.client.Get(ctx, &fulfillmentv1.HostPoolsGetRequest{
 		Id: data.ID.ValueString(),
 	})
+	if removeIfNotFound(ctx, err, "host pool", data.ID, resp) {
+		return
+	}
 	if err != nil {
-		resp.Diagnostics.AddError("Failed to read host pool", err.Error())
+		resp.Diagnostics.AddError("Failed to read host pool", operationErrorDetail("read", "host pool", data.Name, data.ID.ValueString(), err))
 		return
 	}
 
@@ -227,9 +354,16 @@ func (r *HostPoolResource) Read(ctx context.Context, req resource.ReadRequest, r
 }
 
 func (r *HostPoolResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
-	var data HostPoolResourceModel
+	var data, state HostPoolResourceModel
 
 	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	updateTimeout, diags := data.Timeouts.Update(ctx, waiter.DefaultUpdateTimeout)
+	resp.Diagnostics.Append(diags...)
 	if resp.Diagnostics.HasError() {
 		return
 	}
@@ -254,51 +388,93 @@ func (r *HostPoolResource) Update(ctx context.Context, req resource.UpdateReques
 		}
 	}
 
+	// The name is always sent, so that renaming the pool or removing the name from the configuration is applied
+	// in place. A null name is sent as empty, which clears it.
 	hostPool := &fulfillmentv1.HostPool{
-		Id:   data.ID.ValueString(),
+		Id: data.ID.ValueString(),
+		Metadata: &sharedv1.Metadata{
+			Name: data.Name.ValueString(),
+		},
 		Spec: spec,
 	}
 
-	if !data.Name.IsNull() {
-		hostPool.Metadata = &sharedv1.Metadata{
-			Name: data.Name.ValueString(),
+	var specPaths []string
+	if spec.HostSets != nil && !data.HostSets.Equal(state.HostSets) {
+		specPaths = append(specPaths, "spec.host_sets")
+	}
+
+	// The host sets that the backend added on its own aren't in the state, so they are read from the server to be
+	// sent back with the configured ones
+	if len(specPaths) > 0 {
+		getResp, err := r.client.Get(ctx, &fulfillmentv1.HostPoolsGetRequest{
+			Id: data.ID.ValueString(),
+		})
+		if err != nil {
+			resp.Diagnostics.AddError("Failed to read host pool", operationErrorDetail("update", "host pool", data.Name, data.ID.ValueString(), err))
+			return
 		}
+		spec.HostSets = withBackendSets(spec.HostSets, getResp.Object.GetSpec().GetHostSets(), state.HostSets.Elements())
 	}
 
-	updateResp, err := r.client.Update(ctx, &fulfillmentv1.HostPoolsUpdateRequest{
-		Object: hostPool,
-	})
-	if err != nil {
-		resp.Diagnostics.AddError("Failed to update host pool", err.Error())
-		return
+	var updated *fulfillmentv1.HostPool
+	if mask := updateMask(true, data.Name, state.Name, specPaths...); mask != nil {
+		updateResp, err := r.client.Update(ctx, &fulfillmentv1.HostPoolsUpdateRequest{
+			Object:     hostPool,
+			UpdateMask: mask,
+		})
+		if err != nil {
+			resp.Diagnostics.AddError("Failed to update host pool", operationErrorDetail("update", "host pool", data.Name, data.ID.ValueString(), err))
+			return
+		}
+		updated = updateResp.Object
+	} else {
+		// Only attributes of the provider changed, so there is nothing to send, but the host pool is read to refresh
+		// the computed attributes
+		getResp, err := r.client.Get(ctx, &fulfillmentv1.HostPoolsGetRequest{
+			Id: data.ID.ValueString(),
+		})
+		if err != nil {
+			resp.Diagnostics.AddError("Failed to read host pool", operationErrorDetail("read", "host pool", data.Name, data.ID.ValueString(), err))
+			return
+		}
+		updated = getResp.Object
 	}
 
-	hostPoolID := updateResp.Object.Id
+	hostPoolID := updated.Id
 
-	// Wait for host pool to reach READY state
-	result, err := WaitForReady(ctx, WaitForReadyConfig{
-		PendingStates: []string{
-			fulfillmentv1.HostPoolState_HOST_POOL_STATE_UNSPECIFIED.String(),
-			fulfillmentv1.HostPoolState_HOST_POOL_STATE_PROGRESSING.String(),
-		},
-		TargetStates: []string{
-			fulfillmentv1.HostPoolState_HOST_POOL_STATE_READY.String(),
-		},
-		RefreshFunc: r.hostPoolStateRefreshFunc(ctx, hostPoolID),
-		Timeout:     DefaultUpdateTimeout,
-	})
-	if err != nil {
-		resp.Diagnostics.AddError(
-			"Error waiting for host pool to be ready after update",
-			fmt.Sprintf("Host pool %s: %s", hostPoolID, err.Error()),
-		)
-		return
+	// Wait for the host pool to be ready, unless waiting is disabled
+	finalHostPool := updated
+	if shouldWait(data.WaitForReady, r.wait) {
+		result, err := waiter.WaitForReady(ctx, waiter.Config{
+			PendingStates: []string{
+				fulfillmentv1.HostPoolState_HOST_POOL_STATE_UNSPECIFIED.String(),
+				fulfillmentv1.HostPoolState_HOST_POOL_STATE_PROGRESSING.String(),
+			},
+			TargetStates: []string{
+				fulfillmentv1.HostPoolState_HOST_POOL_STATE_READY.String(),
+			},
+			RefreshFunc:       r.hostPoolStateRefreshFunc(ctx, hostPoolID),
+			Timeout:           updateTimeout,
+			FixedPollInterval: r.wait.PollInterval,
+			MinPollInterval:   r.wait.MinPollInterval,
+			BackoffMultiplier: r.wait.BackoffMultiplier,
+			MaxPollInterval:   r.wait.MaxPollInterval,
+			SlowWarningAfter:  r.wait.SlowWarningAfter,
+		})
+		if err != nil {
+			resp.Diagnostics.AddError(
+				"Error waiting for host pool to be ready after update",
+				operationErrorDetail("update", "host pool", data.Name, hostPoolID, err),
+			)
+			return
+		}
+		finalHostPool = result.(*fulfillmentv1.HostPool)
 	}
 
 	// Update state with the final host pool data
-	finalHostPool := result.(*fulfillmentv1.HostPool)
 	r.updateModelFromHostPool(ctx, &data, finalHostPool, &resp.Diagnostics)
 
+	resp.Diagnostics.Append(recordConfiguredName(ctx, req.Config, resp.Private)...)
 	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
 }
 
@@ -310,22 +486,124 @@ func (r *HostPoolResource) Delete(ctx context.Context, req resource.DeleteReques
 		return
 	}
 
+	if checkDeletionProtection(data.DeletionProtection, "host pool", data.Name, data.ID.ValueString(), &resp.Diagnostics) {
+		return
+	}
+
+	deleteTimeout, diags := data.Timeouts.Delete(ctx, waiter.DefaultDeleteTimeout)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	hostPoolID := data.ID.ValueString()
 	_, err := r.client.Delete(ctx, &fulfillmentv1.HostPoolsDeleteRequest{
-		Id: data.ID.ValueString(),
+		Id: hostPoolID,
 	})
+	if isNotFound(err) {
+		return
+	}
 	if err != nil {
-		resp.Diagnostics.AddError("Failed to delete host pool", err.Error())
+		resp.Diagnostics.AddError("Failed to delete host pool", operationErrorDetail("delete", "host pool", data.Name, hostPoolID, err))
+		return
+	}
+
+	if !shouldWait(data.WaitForReady, r.wait) {
+		return
+	}
+
+	// Wait for the host pool to disappear
+	_, err = waiter.WaitForState(ctx, waiter.Config{
+		PendingStates: deletePendingStates(fulfillmentv1.HostPoolState_name,
+			int32(fulfillmentv1.HostPoolState_HOST_POOL_STATE_FAILED)),
+		TargetStates:      []string{waiter.DeletedState},
+		RefreshFunc:       r.hostPoolDeleteRefreshFunc(ctx, hostPoolID),
+		Timeout:           deleteTimeout,
+		FixedPollInterval: r.wait.PollInterval,
+		MinPollInterval:   r.wait.MinPollInterval,
+		BackoffMultiplier: r.wait.BackoffMultiplier,
+		MaxPollInterval:   r.wait.MaxPollInterval,
+		SlowWarningAfter:  r.wait.SlowWarningAfter,
+	})
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error waiting for host pool to be deleted",
+			operationErrorDetail("delete", "host pool", data.Name, hostPoolID, err),
+		)
 		return
 	}
 }
 
+// hostPoolDeleteRefreshFunc returns a StateRefreshFunc used while the host pool is being deleted. It reports the
+// DeletedState pseudo state once the host pool isn't found, and an error including the reason if it fails.
+func (r *HostPoolResource) hostPoolDeleteRefreshFunc(ctx context.Context, hostPoolID string) waiter.StateRefreshFunc {
+	return waiter.LogTransitions(ctx, "host pool", hostPoolID, func() (interface{}, string, error) {
+		getResp, err := r.client.Get(ctx, &fulfillmentv1.HostPoolsGetRequest{Id: hostPoolID})
+		if isNotFound(err) {
+			return hostPoolID, waiter.DeletedState, nil
+		}
+		if err != nil {
+			return nil, "", fmt.Errorf("failed to get host pool: %w", err)
+		}
+
+		hostPool := getResp.Object
+		if hostPool.Status == nil {
+			return hostPool, fulfillmentv1.HostPoolState_HOST_POOL_STATE_UNSPECIFIED.String(), nil
+		}
+
+		state := hostPool.Status.State
+		if state == fulfillmentv1.HostPoolState_HOST_POOL_STATE_FAILED {
+			return nil, state.String(), fmt.Errorf("host pool reached FAILED state while being deleted%s",
+				client.FailureReason(hostPool.Status.Conditions))
+		}
+
+		return hostPool, state.String(), nil
+	})
+}
+
+func (r *HostPoolResource) ConfigValidators(ctx context.Context) []resource.ConfigValidator {
+	return []resource.ConfigValidator{
+		resourcevalidator.Conflicting(path.MatchRoot("name"), path.MatchRoot("name_prefix")),
+	}
+}
+
+func (r *HostPoolResource) ModifyPlan(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	planClearableName(ctx, req, resp)
+	if resp.Diagnostics.HasError() || req.Plan.Raw.IsNull() || req.State.Raw.IsNull() {
+		return
+	}
+
+	// The host class of an existing host set can't be changed, so reject it in the plan instead of failing the apply
+	var planned, current types.Map
+	resp.Diagnostics.Append(req.Plan.GetAttribute(ctx, path.Root("host_sets"), &planned)...)
+	resp.Diagnostics.Append(req.State.GetAttribute(ctx, path.Root("host_sets"), &current)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	checkImmutableHostClasses(path.Root("host_sets"), hostSetHostClasses(ctx, planned, &resp.Diagnostics),
+		hostSetHostClasses(ctx, current, &resp.Diagnostics), &resp.Diagnostics)
+}
+
 func (r *HostPoolResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
-	resource.ImportStatePassthroughID(ctx, path.Root("id"), req, resp)
+	importState(ctx, req, resp, func(name string) (string, error) {
+		filter := client.NameFilter(name)
+		listResp, err := r.client.List(ctx, &fulfillmentv1.HostPoolsListRequest{
+			Filter: &filter,
+		})
+		if err != nil {
+			return "", err
+		}
+		found, err := client.SelectByName("host pool", name, listResp.Items)
+		if err != nil {
+			return "", err
+		}
+		return found.Id, nil
+	})
 }
 
 // hostPoolStateRefreshFunc returns a StateRefreshFunc that fetches the host pool and returns its state.
-func (r *HostPoolResource) hostPoolStateRefreshFunc(ctx context.Context, hostPoolID string) StateRefreshFunc {
-	return func() (interface{}, string, error) {
+func (r *HostPoolResource) hostPoolStateRefreshFunc(ctx context.Context, hostPoolID string) waiter.StateRefreshFunc {
+	return waiter.LogTransitions(ctx, "host pool", hostPoolID, func() (interface{}, string, error) {
 		getResp, err := r.client.Get(ctx, &fulfillmentv1.HostPoolsGetRequest{Id: hostPoolID})
 		if err != nil {
 			return nil, "", fmt.Errorf("failed to get host pool: %w", err)
@@ -338,36 +616,45 @@ func (r *HostPoolResource) hostPoolStateRefreshFunc(ctx context.Context, hostPoo
 
 		state := hostPool.Status.State
 		if state == fulfillmentv1.HostPoolState_HOST_POOL_STATE_FAILED {
-			return nil, state.String(), fmt.Errorf("host pool reached FAILED state")
+			return nil, state.String(), fmt.Errorf("host pool reached FAILED state%s", client.FailureReason(hostPool.Status.Conditions))
 		}
 
 		return hostPool, state.String(), nil
-	}
+	})
 }
 
 func (r *HostPoolResource) updateModelFromHostPool(ctx context.Context, model *HostPoolResourceModel, hostPool *fulfillmentv1.HostPool, diags *diag.Diagnostics) {
 	model.ID = types.StringValue(hostPool.Id)
+	model.ShortID = types.StringValue(shortID(hostPool.Id))
 
-	if hostPool.Metadata != nil {
+	if hostPool.Metadata != nil && hostPool.Metadata.Name != "" {
 		model.Name = types.StringValue(hostPool.Metadata.Name)
+	} else if hostPool.Metadata != nil || model.Name.IsUnknown() {
+		model.Name = types.StringNull()
 	}
-
-	if hostPool.Spec != nil && hostPool.Spec.HostSets != nil {
+	model.CreatedAt = client.CreatedAtValue(hostPool.Metadata)
+
+	// The host sets are always taken from the spec, so that they are fully reconstructed after an import, but those
+	// that the backend added on its own are ignored once host sets are known. A pool without host sets is stored as
+	// null, unless an empty map was configured.
+	hostSetType := types.ObjectType{AttrTypes: hostSetAttrTypes}
+	var specHostSets map[string]*fulfillmentv1.HostPoolHostSet
+	if hostPool.Spec != nil {
+		specHostSets = configuredSets(model.HostSets, hostPool.Spec.HostSets)
+	}
+	if len(specHostSets) > 0 {
 		hostSets := make(map[string]HostSetModel)
-		for name, hs := range hostPool.Spec.HostSets {
+		for name, hs := range specHostSets {
 			hostSets[name] = HostSetModel{
 				HostClass: types.StringValue(hs.HostClass),
 				Size:      types.Int32Value(hs.Size),
 			}
 		}
-		hostSetsValue, d := types.MapValueFrom(ctx, types.ObjectType{
-			AttrTypes: map[string]attr.Type{
-				"host_class": types.StringType,
-				"size":       types.Int32Type,
-			},
-		}, hostSets)
+		hostSetsValue, d := types.MapValueFrom(ctx, hostSetType, hostSets)
 		diags.Append(d...)
 		model.HostSets = hostSetsValue
+	} else if model.HostSets.IsUnknown() || len(model.HostSets.Elements()) > 0 {
+		model.HostSets = types.MapNull(hostSetType)
 	}
 
 	if hostPool.Status != nil {
@@ -385,4 +672,56 @@ func (r *HostPoolResource) updateModelFromHostPool(ctx context.Context, model *H
 		model.State = types.StringNull()
 		model.Hosts = types.ListNull(types.StringType)
 	}
+
+	model.ReadyHosts = types.ListNull(types.StringType)
+	model.FailedHosts = types.ListNull(types.StringType)
+	if model.ReadHostStates.ValueBool() && hostPool.Status != nil {
+		r.updateModelHostStates(ctx, model, hostPool.Status.Hosts, diags)
+	}
+}
+
+// updateModelHostStates reads each host of the pool and splits them into ready and failed hosts. Hosts that are
+// still progressing appear in neither list.
+func (r *HostPoolResource) updateModelHostStates(ctx context.Context, model *HostPoolResourceModel, hostIDs []string, diags *diag.Diagnostics) {
+	readyHosts := []string{}
+	failedHosts := []string{}
+	for _, hostID := range hostIDs {
+		getResp, err := r.hostsClient.Get(ctx, &fulfillmentv1.HostsGetRequest{Id: hostID})
+		if err != nil {
+			diags.AddError("Failed to read host of host pool", fmt.Sprintf("Host %s: %s", hostID, err.Error()))
+			return
+		}
+		if getResp.Object.Status == nil {
+			continue
+		}
+		switch getResp.Object.Status.State {
+		case fulfillmentv1.HostState_HOST_STATE_READY:
+			readyHosts = append(readyHosts, hostID)
+		case fulfillmentv1.HostState_HOST_STATE_FAILED:
+			failedHosts = append(failedHosts, hostID)
+		}
+	}
+
+	readyHostsValue, d := types.ListValueFrom(ctx, types.StringType, readyHosts)
+	diags.Append(d...)
+	model.ReadyHosts = readyHostsValue
+	failedHostsValue, d := types.ListValueFrom(ctx, types.StringType, failedHosts)
+	diags.Append(d...)
+	model.FailedHosts = failedHostsValue
+}
+
+// hostSetHostClasses returns the host class of each host set, indexed by host set name.
+func hostSetHostClasses(ctx context.Context, hostSets types.Map, diags *diag.Diagnostics) map[string]string {
+	if hostSets.IsNull() || hostSets.IsUnknown() {
+		return nil
+	}
+
+	hostSetsMap := make(map[string]HostSetModel)
+	diags.Append(hostSets.ElementsAs(ctx, &hostSetsMap, false)...)
+
+	hostClasses := make(map[string]string, len(hostSetsMap))
+	for name, hs := range hostSetsMap {
+		hostClasses[name] = hs.HostClass.ValueString()
+	}
+	return hostClasses
 }
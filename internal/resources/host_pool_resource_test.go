@@ -0,0 +1,368 @@
+/*
+Copyright (c) 2025 Red Hat Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License"); you may not use this file except in compliance with the
+License. You may obtain a copy of the License at
+
+  http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software distributed under the License is distributed on an
+"AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the License for the specific
+language governing permissions and limitations under the License.
+*/
+
+package resources
+
+import (
+	"context"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+	"google.golang.org/grpc"
+
+	fulfillmentv1 "github.com/innabox/fulfillment-common/api/fulfillment/v1"
+	sharedv1 "github.com/innabox/fulfillment-common/api/shared/v1"
+)
+
+// fakeHostPoolsClient is a host pools client that returns the given host pool from Get. Other methods aren't
+// implemented.
+type fakeHostPoolsClient struct {
+	fulfillmentv1.HostPoolsClient
+	hostPool *fulfillmentv1.HostPool
+}
+
+func (c *fakeHostPoolsClient) Get(ctx context.Context, in *fulfillmentv1.HostPoolsGetRequest,
+	opts ...grpc.CallOption) (*fulfillmentv1.HostPoolsGetResponse, error) {
+	return &fulfillmentv1.HostPoolsGetResponse{Object: c.hostPool}, nil
+}
+
+func TestUpdateModelFromHostPoolHostSets(t *testing.T) {
+	hostSetType := types.ObjectType{AttrTypes: hostSetAttrTypes}
+	hostSet := func(hostClass string, size int32) attr.Value {
+		return types.ObjectValueMust(hostSetAttrTypes, map[string]attr.Value{
+			"host_class": types.StringValue(hostClass),
+			"size":       types.Int32Value(size),
+		})
+	}
+	specHostSets := map[string]*fulfillmentv1.HostPoolHostSet{
+		"small": {HostClass: "acme_1tb", Size: 2},
+		"large": {HostClass: "acme_4tb", Size: 1},
+	}
+	tests := []struct {
+		name     string
+		current  types.Map
+		spec     map[string]*fulfillmentv1.HostPoolHostSet
+		expected types.Map
+	}{
+		{
+			name:    "Reconstructed after an import",
+			current: types.MapNull(hostSetType),
+			spec:    specHostSets,
+			expected: types.MapValueMust(hostSetType, map[string]attr.Value{
+				"small": hostSet("acme_1tb", 2),
+				"large": hostSet("acme_4tb", 1),
+			}),
+		},
+		{
+			name: "Sets added by the backend are ignored",
+			current: types.MapValueMust(hostSetType, map[string]attr.Value{
+				"small": hostSet("acme_1tb", 3),
+			}),
+			spec: specHostSets,
+			expected: types.MapValueMust(hostSetType, map[string]attr.Value{
+				"small": hostSet("acme_1tb", 2),
+			}),
+		},
+		{
+			name:     "No host sets",
+			current:  types.MapUnknown(hostSetType),
+			expected: types.MapNull(hostSetType),
+		},
+		{
+			name:     "Configured empty map is kept",
+			current:  types.MapValueMust(hostSetType, map[string]attr.Value{}),
+			expected: types.MapValueMust(hostSetType, map[string]attr.Value{}),
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			model := HostPoolResourceModel{
+				HostSets: test.current,
+			}
+			hostPool := &fulfillmentv1.HostPool{
+				Id: "my-pool",
+				Spec: &fulfillmentv1.HostPoolSpec{
+					HostSets: test.spec,
+				},
+			}
+			var diags diag.Diagnostics
+			r := &HostPoolResource{}
+			r.updateModelFromHostPool(context.Background(), &model, hostPool, &diags)
+			if diags.HasError() {
+				t.Fatalf("unexpected errors: %v", diags)
+			}
+			if !model.HostSets.Equal(test.expected) {
+				t.Errorf("expected %s, got %s", test.expected, model.HostSets)
+			}
+		})
+	}
+}
+
+func TestHostPoolRenameUpdatesInPlace(t *testing.T) {
+	ctx := context.Background()
+	r := NewHostPoolResource()
+	s := resourceSchema(t, r)
+	state := objectValue(s, map[string]tftypes.Value{
+		"id":   tftypes.NewValue(tftypes.String, "my-pool"),
+		"name": tftypes.NewValue(tftypes.String, "old"),
+	})
+	config := objectValue(s, map[string]tftypes.Value{
+		"name": tftypes.NewValue(tftypes.String, "new"),
+	})
+	plan := objectValue(s, map[string]tftypes.Value{
+		"id":   tftypes.NewValue(tftypes.String, "my-pool"),
+		"name": tftypes.NewValue(tftypes.String, "new"),
+	})
+	req := resource.ModifyPlanRequest{
+		Config: tfsdk.Config{Schema: s, Raw: config},
+		State:  tfsdk.State{Schema: s, Raw: state},
+		Plan:   tfsdk.Plan{Schema: s, Raw: plan},
+	}
+	req.Private = newPrivateState(req.Private)
+	resp := &resource.ModifyPlanResponse{Plan: req.Plan}
+
+	// Like the framework, run the plan modifiers of the string attributes, which are the ones that can require a
+	// replacement when the name changes, before the ModifyPlan method of the resource
+	for name, attribute := range s.Attributes {
+		stringAttribute, ok := attribute.(schema.StringAttribute)
+		if !ok {
+			continue
+		}
+		attributePath := path.Root(name)
+		modifierReq := planmodifier.StringRequest{
+			Path:   attributePath,
+			Config: req.Config,
+			State:  req.State,
+			Plan:   req.Plan,
+		}
+		diags := req.Config.GetAttribute(ctx, attributePath, &modifierReq.ConfigValue)
+		diags.Append(req.State.GetAttribute(ctx, attributePath, &modifierReq.StateValue)...)
+		diags.Append(req.Plan.GetAttribute(ctx, attributePath, &modifierReq.PlanValue)...)
+		if diags.HasError() {
+			t.Fatalf("unexpected errors: %v", diags)
+		}
+		for _, modifier := range stringAttribute.PlanModifiers {
+			modifierResp := &planmodifier.StringResponse{PlanValue: modifierReq.PlanValue}
+			modifier.PlanModifyString(ctx, modifierReq, modifierResp)
+			if modifierResp.RequiresReplace {
+				resp.RequiresReplace = append(resp.RequiresReplace, attributePath)
+			}
+		}
+	}
+	r.(resource.ResourceWithModifyPlan).ModifyPlan(ctx, req, resp)
+	if resp.Diagnostics.HasError() {
+		t.Fatalf("unexpected errors: %v", resp.Diagnostics)
+	}
+
+	if len(resp.RequiresReplace) > 0 {
+		t.Errorf("expected an update in place, got a replacement for %v", resp.RequiresReplace)
+	}
+	var name types.String
+	resp.Diagnostics.Append(resp.Plan.GetAttribute(ctx, path.Root("name"), &name)...)
+	if name.ValueString() != "new" {
+		t.Errorf("expected name new, got %s", name)
+	}
+}
+
+func TestHostPoolModifyPlanHostClasses(t *testing.T) {
+	hostSetType := tftypes.Object{AttributeTypes: map[string]tftypes.Type{
+		"host_class": tftypes.String,
+		"size":       tftypes.Number,
+	}}
+	hostSets := func(sets map[string]string) tftypes.Value {
+		values := make(map[string]tftypes.Value, len(sets))
+		for name, hostClass := range sets {
+			values[name] = tftypes.NewValue(hostSetType, map[string]tftypes.Value{
+				"host_class": tftypes.NewValue(tftypes.String, hostClass),
+				"size":       tftypes.NewValue(tftypes.Number, 2),
+			})
+		}
+		return tftypes.NewValue(tftypes.Map{ElementType: hostSetType}, values)
+	}
+	tests := []struct {
+		name    string
+		state   map[string]string
+		planned map[string]string
+		fails   bool
+	}{
+		{
+			name:    "Unchanged",
+			state:   map[string]string{"small": "acme_1tb"},
+			planned: map[string]string{"small": "acme_1tb"},
+		},
+		{
+			name:    "Set replaced by a new one",
+			state:   map[string]string{"small": "acme_1tb"},
+			planned: map[string]string{"medium": "acme_2tb"},
+		},
+		{
+			name:    "Host class changed",
+			state:   map[string]string{"small": "acme_1tb"},
+			planned: map[string]string{"small": "acme_2tb"},
+			fails:   true,
+		},
+		{
+			name:    "Created",
+			planned: map[string]string{"small": "acme_1tb"},
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			ctx := context.Background()
+			r := NewHostPoolResource()
+			s := resourceSchema(t, r)
+			planned := objectValue(s, map[string]tftypes.Value{
+				"id":        tftypes.NewValue(tftypes.String, "my-pool"),
+				"name":      tftypes.NewValue(tftypes.String, "my-pool"),
+				"host_sets": hostSets(test.planned),
+			})
+			state := tftypes.NewValue(s.Type().TerraformType(ctx), nil)
+			if test.state != nil {
+				state = objectValue(s, map[string]tftypes.Value{
+					"id":        tftypes.NewValue(tftypes.String, "my-pool"),
+					"name":      tftypes.NewValue(tftypes.String, "my-pool"),
+					"host_sets": hostSets(test.state),
+				})
+			}
+			req := resource.ModifyPlanRequest{
+				Config: tfsdk.Config{Schema: s, Raw: planned},
+				State:  tfsdk.State{Schema: s, Raw: state},
+				Plan:   tfsdk.Plan{Schema: s, Raw: planned},
+			}
+			resp := &resource.ModifyPlanResponse{Plan: req.Plan}
+
+			r.(resource.ResourceWithModifyPlan).ModifyPlan(ctx, req, resp)
+			if resp.Diagnostics.HasError() != test.fails {
+				t.Errorf("expected failure %t, got diagnostics %v", test.fails, resp.Diagnostics)
+			}
+		})
+	}
+}
+
+func TestHostPoolImportHasNoDiff(t *testing.T) {
+	hostSetType := tftypes.Object{AttributeTypes: map[string]tftypes.Type{
+		"host_class": tftypes.String,
+		"size":       tftypes.Number,
+	}}
+	hostSets := tftypes.NewValue(tftypes.Map{ElementType: hostSetType}, map[string]tftypes.Value{
+		"small": tftypes.NewValue(hostSetType, map[string]tftypes.Value{
+			"host_class": tftypes.NewValue(tftypes.String, "acme_1tb"),
+			"size":       tftypes.NewValue(tftypes.Number, 2),
+		}),
+	})
+	tests := []struct {
+		name   string
+		config map[string]tftypes.Value
+	}{
+		{
+			name: "Name configured",
+			config: map[string]tftypes.Value{
+				"name":      tftypes.NewValue(tftypes.String, "my-pool"),
+				"host_sets": hostSets,
+			},
+		},
+		{
+			name: "Name omitted",
+			config: map[string]tftypes.Value{
+				"host_sets": hostSets,
+			},
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			ctx := context.Background()
+			r := &HostPoolResource{
+				client: &fakeHostPoolsClient{
+					hostPool: &fulfillmentv1.HostPool{
+						Id:       "0123456789abcdef",
+						Metadata: &sharedv1.Metadata{Name: "my-pool"},
+						Spec: &fulfillmentv1.HostPoolSpec{
+							HostSets: map[string]*fulfillmentv1.HostPoolHostSet{
+								"small": {HostClass: "acme_1tb", Size: 2},
+							},
+						},
+						Status: &fulfillmentv1.HostPoolStatus{
+							State: fulfillmentv1.HostPoolState_HOST_POOL_STATE_READY,
+							Hosts: []string{"host-1", "host-2"},
+						},
+					},
+				},
+			}
+			s := resourceSchema(t, r)
+
+			importReq := resource.ImportStateRequest{ID: "0123456789abcdef"}
+			importResp := &resource.ImportStateResponse{
+				State: tfsdk.State{Schema: s, Raw: tftypes.NewValue(s.Type().TerraformType(ctx), nil)},
+			}
+			importResp.Private = newPrivateState(importResp.Private)
+			r.ImportState(ctx, importReq, importResp)
+			if importResp.Diagnostics.HasError() {
+				t.Fatalf("unexpected import errors: %v", importResp.Diagnostics)
+			}
+
+			readReq := resource.ReadRequest{State: importResp.State, Private: importResp.Private}
+			readResp := &resource.ReadResponse{State: readReq.State, Private: readReq.Private}
+			r.Read(ctx, readReq, readResp)
+			if readResp.Diagnostics.HasError() {
+				t.Fatalf("unexpected read errors: %v", readResp.Diagnostics)
+			}
+
+			// Like Terraform, propose the configured values, and the state for the computed attributes that aren't
+			// configured
+			config := objectValue(s, test.config)
+			var configAttributes, stateAttributes map[string]tftypes.Value
+			if err := config.As(&configAttributes); err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if err := readResp.State.Raw.As(&stateAttributes); err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			proposed := make(map[string]tftypes.Value, len(configAttributes))
+			for name, value := range configAttributes {
+				proposed[name] = value
+				if attribute, ok := s.Attributes[name]; ok && value.IsNull() && attribute.IsComputed() {
+					proposed[name] = stateAttributes[name]
+				}
+			}
+			plan := tftypes.NewValue(config.Type(), proposed)
+
+			planReq := resource.ModifyPlanRequest{
+				Config:  tfsdk.Config{Schema: s, Raw: config},
+				State:   readResp.State,
+				Plan:    tfsdk.Plan{Schema: s, Raw: plan},
+				Private: readResp.Private,
+			}
+			planResp := &resource.ModifyPlanResponse{Plan: planReq.Plan}
+			r.ModifyPlan(ctx, planReq, planResp)
+			if planResp.Diagnostics.HasError() {
+				t.Fatalf("unexpected plan errors: %v", planResp.Diagnostics)
+			}
+
+			if !planResp.Plan.Raw.Equal(readResp.State.Raw) {
+				diffs, _ := readResp.State.Raw.Diff(planResp.Plan.Raw)
+				t.Errorf("expected no diff after the import, got %v", diffs)
+			}
+			if len(planResp.RequiresReplace) > 0 {
+				t.Errorf("expected no replacement, got a replacement for %v", planResp.RequiresReplace)
+			}
+		})
+	}
+}
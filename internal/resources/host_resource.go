@@ -16,23 +16,32 @@ package resources
 import (
 	"context"
 	"fmt"
+	"strings"
+	"time"
 
+	"github.com/hashicorp/terraform-plugin-framework-timeouts/resource/timeouts"
+	"github.com/hashicorp/terraform-plugin-framework-validators/resourcevalidator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
 	"github.com/hashicorp/terraform-plugin-framework/path"
 	"github.com/hashicorp/terraform-plugin-framework/resource"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
 	"github.com/hashicorp/terraform-plugin-framework/types"
 
 	fulfillmentv1 "github.com/innabox/fulfillment-common/api/fulfillment/v1"
 	sharedv1 "github.com/innabox/fulfillment-common/api/shared/v1"
 
 	"github.com/innabox/terraform-provider-osac/internal/client"
+	"github.com/innabox/terraform-provider-osac/internal/waiter"
 )
 
 // Ensure provider defined types fully satisfy framework interfaces.
 var _ resource.Resource = &HostResource{}
 var _ resource.ResourceWithImportState = &HostResource{}
+var _ resource.ResourceWithModifyPlan = &HostResource{}
+var _ resource.ResourceWithConfigValidators = &HostResource{}
 
 func NewHostResource() resource.Resource {
 	return &HostResource{}
@@ -41,16 +50,22 @@ func NewHostResource() resource.Resource {
 // HostResource defines the resource implementation.
 type HostResource struct {
 	client fulfillmentv1.HostsClient
+	wait   client.WaitSettings
 }
 
 // HostResourceModel describes the resource data model.
 type HostResourceModel struct {
-	ID         types.String `tfsdk:"id"`
-	Name       types.String `tfsdk:"name"`
-	PowerState types.String `tfsdk:"power_state"`
+	ID           types.String `tfsdk:"id"`
+	ShortID      types.String `tfsdk:"short_id"`
+	CreatedAt    types.String `tfsdk:"created_at"`
+	Name         types.String `tfsdk:"name"`
+	NamePrefix   types.String `tfsdk:"name_prefix"`
+	PowerState   types.String `tfsdk:"power_state"`
+	WaitForReady types.Bool   `tfsdk:"wait_for_ready"`
 	// Computed status fields
-	State             types.String `tfsdk:"state"`
-	CurrentPowerState types.String `tfsdk:"current_power_state"`
+	State             types.String   `tfsdk:"state"`
+	CurrentPowerState types.String   `tfsdk:"current_power_state"`
+	Timeouts          timeouts.Value `tfsdk:"timeouts"`
 }
 
 func (r *HostResource) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
@@ -68,13 +83,48 @@ func (r *HostResource) Schema(ctx context.Context, req resource.SchemaRequest, r
 					stringplanmodifier.UseStateForUnknown(),
 				},
 			},
+			"short_id": schema.StringAttribute{
+				Description: "Short form of the identifier: its first 8 characters, or the whole identifier when " +
+					"it is shorter. It never changes for the same host.",
+				Computed: true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"created_at": schema.StringAttribute{
+				Description: "Time when the host was created, in RFC 3339 format.",
+				Computed:    true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
 			"name": schema.StringAttribute{
-				Description: "Human-friendly name of the host.",
-				Optional:    true,
+				Description: "Human-friendly name of the host. When omitted and name_prefix is set, a unique name is " +
+					"generated from the prefix.",
+				Optional: true,
+				Computed: true,
+			},
+			"name_prefix": schema.StringAttribute{
+				Description: "Prefix used to generate a unique name of the form <prefix>-<random suffix> when name " +
+					"is not set. Changing it forces a new host with a newly generated name.",
+				Optional: true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
 			},
 			"power_state": schema.StringAttribute{
 				Description: "Desired power state of the host (ON, OFF).",
 				Optional:    true,
+				Validators: []validator.String{
+					stringvalidator.OneOf("ON", "OFF", "HOST_POWER_STATE_ON", "HOST_POWER_STATE_OFF"),
+				},
+			},
+			"wait_for_ready": schema.BoolAttribute{
+				Description: "Wait for the host to report the desired power state when it is created or updated, and to " +
+					"disappear when it is destroyed. " +
+					"Defaults to the wait_for_ready setting of the provider, which defaults to true. When false, only " +
+					"the status returned right away is stored.",
+				Optional: true,
 			},
 			"state": schema.StringAttribute{
 				Description: "Current state of the host (PROGRESSING, READY, FAILED).",
@@ -85,6 +135,13 @@ func (r *HostResource) Schema(ctx context.Context, req resource.SchemaRequest, r
 				Computed:    true,
 			},
 		},
+		Blocks: map[string]schema.Block{
+			"timeouts": timeouts.Block(ctx, timeouts.Opts{
+				Create: true,
+				Update: true,
+				Delete: true,
+			}),
+		},
 	}
 }
 
@@ -103,6 +160,7 @@ func (r *HostResource) Configure(ctx context.Context, req resource.ConfigureRequ
 	}
 
 	r.client = providerData.HostsClient
+	r.wait = providerData.Wait
 }
 
 func (r *HostResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
@@ -113,6 +171,12 @@ func (r *HostResource) Create(ctx context.Context, req resource.CreateRequest, r
 		return
 	}
 
+	createTimeout, diags := data.Timeouts.Create(ctx, waiter.DefaultPowerStateTimeout)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
 	// Build the host spec
 	spec := &fulfillmentv1.HostSpec{}
 
@@ -125,8 +189,13 @@ func (r *HostResource) Create(ctx context.Context, req resource.CreateRequest, r
 		Spec: spec,
 	}
 
+	// Generate the name from the prefix if needed
+	if data.Name.IsUnknown() && !data.NamePrefix.IsNull() {
+		data.Name = types.StringValue(generateName(data.NamePrefix.ValueString()))
+	}
+
 	// Set metadata if name is provided
-	if !data.Name.IsNull() {
+	if !data.Name.IsNull() && !data.Name.IsUnknown() {
 		host.Metadata = &sharedv1.Metadata{
 			Name: data.Name.ValueString(),
 		}
@@ -137,13 +206,29 @@ func (r *HostResource) Create(ctx context.Context, req resource.CreateRequest, r
 		Object: host,
 	})
 	if err != nil {
-		resp.Diagnostics.AddError("Failed to create host", err.Error())
+		resp.Diagnostics.AddError("Failed to create host", operationErrorDetail("create", "host", data.Name, "", err))
 		return
 	}
 
-	// Update state with response
-	r.updateModelFromHost(&data, createResp.Object)
+	// Wait for the host to reach the desired power state, unless waiting is disabled
+	finalHost := createResp.Object
+	if spec.PowerState != fulfillmentv1.HostPowerState_HOST_POWER_STATE_UNSPECIFIED && shouldWait(data.WaitForReady, r.wait) {
+		result, err := r.waitForPowerState(ctx, finalHost.Id, spec.PowerState, createTimeout)
+		if err != nil {
+			saveCreatedID(ctx, resp, finalHost.Id)
+			resp.Diagnostics.AddError(
+				"Error waiting for host to reach the desired power state",
+				operationErrorDetail("create", "host", data.Name, finalHost.Id, err),
+			)
+			return
+		}
+		finalHost = result
+	}
+
+	// Update state with the final host data
+	r.updateModelFromHost(&data, finalHost)
 
+	resp.Diagnostics.Append(recordConfiguredName(ctx, req.Config, resp.Private)...)
 	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
 }
 
@@ -158,20 +243,38 @@ func (r *HostResource) Read(ctx context.Context, req resource.ReadRequest, resp
 	getResp, err := r.client.Get(ctx, &fulfillmentv1.HostsGetRequest{
 		Id: data.ID.ValueString(),
 	})
+	if removeIfNotFound(ctx, err, "host", data.ID, resp) {
+		return
+	}
 	if err != nil {
-		resp.Diagnostics.AddError("Failed to read host", err.Error())
+		resp.Diagnostics.AddError("Failed to read host", operationErrorDetail("read", "host", data.Name, data.ID.ValueString(), err))
 		return
 	}
 
 	r.updateModelFromHost(&data, getResp.Object)
 
+	// The desired power state is normally kept as configured, but after an import it has to be taken from the spec.
+	if isFirstReadAfterImport(ctx, req, resp) && getResp.Object.Spec != nil &&
+		getResp.Object.Spec.PowerState != fulfillmentv1.HostPowerState_HOST_POWER_STATE_UNSPECIFIED {
+		data.PowerState = types.StringValue(formatPowerState(getResp.Object.Spec.PowerState, ""))
+	}
+
+	data.PowerState = driftedPowerState(data.PowerState, getResp.Object)
+
 	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
 }
 
 func (r *HostResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
-	var data HostResourceModel
+	var data, state HostResourceModel
 
 	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	updateTimeout, diags := data.Timeouts.Update(ctx, waiter.DefaultPowerStateTimeout)
+	resp.Diagnostics.Append(diags...)
 	if resp.Diagnostics.HasError() {
 		return
 	}
@@ -183,27 +286,63 @@ func (r *HostResource) Update(ctx context.Context, req resource.UpdateRequest, r
 		spec.PowerState = parsePowerState(data.PowerState.ValueString())
 	}
 
+	// The name is always sent, so that renaming the host or removing the name from the configuration is applied
+	// in place. A null name is sent as empty, which clears it.
 	host := &fulfillmentv1.Host{
-		Id:   data.ID.ValueString(),
+		Id: data.ID.ValueString(),
+		Metadata: &sharedv1.Metadata{
+			Name: data.Name.ValueString(),
+		},
 		Spec: spec,
 	}
 
-	if !data.Name.IsNull() {
-		host.Metadata = &sharedv1.Metadata{
-			Name: data.Name.ValueString(),
+	// Without a configured power state, the one of the server is left alone
+	var specPaths []string
+	if !data.PowerState.IsNull() && !data.PowerState.Equal(state.PowerState) {
+		specPaths = append(specPaths, "spec.power_state")
+	}
+
+	var updated *fulfillmentv1.Host
+	if mask := updateMask(true, data.Name, state.Name, specPaths...); mask != nil {
+		updateResp, err := r.client.Update(ctx, &fulfillmentv1.HostsUpdateRequest{
+			Object:     host,
+			UpdateMask: mask,
+		})
+		if err != nil {
+			resp.Diagnostics.AddError("Failed to update host", operationErrorDetail("update", "host", data.Name, data.ID.ValueString(), err))
+			return
 		}
+		updated = updateResp.Object
+	} else {
+		// Only attributes of the provider changed, so there is nothing to send, but the host is read to refresh the
+		// computed attributes
+		getResp, err := r.client.Get(ctx, &fulfillmentv1.HostsGetRequest{
+			Id: data.ID.ValueString(),
+		})
+		if err != nil {
+			resp.Diagnostics.AddError("Failed to read host", operationErrorDetail("read", "host", data.Name, data.ID.ValueString(), err))
+			return
+		}
+		updated = getResp.Object
 	}
 
-	updateResp, err := r.client.Update(ctx, &fulfillmentv1.HostsUpdateRequest{
-		Object: host,
-	})
-	if err != nil {
-		resp.Diagnostics.AddError("Failed to update host", err.Error())
-		return
+	// Wait for the host to reach the desired power state, unless waiting is disabled
+	finalHost := updated
+	if spec.PowerState != fulfillmentv1.HostPowerState_HOST_POWER_STATE_UNSPECIFIED && shouldWait(data.WaitForReady, r.wait) {
+		result, err := r.waitForPowerState(ctx, finalHost.Id, spec.PowerState, updateTimeout)
+		if err != nil {
+			resp.Diagnostics.AddError(
+				"Error waiting for host to reach the desired power state",
+				operationErrorDetail("update", "host", data.Name, finalHost.Id, err),
+			)
+			return
+		}
+		finalHost = result
 	}
 
-	r.updateModelFromHost(&data, updateResp.Object)
+	r.updateModelFromHost(&data, finalHost)
 
+	resp.Diagnostics.Append(recordConfiguredName(ctx, req.Config, resp.Private)...)
 	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
 }
 
@@ -215,34 +354,205 @@ func (r *HostResource) Delete(ctx context.Context, req resource.DeleteRequest, r
 		return
 	}
 
+	deleteTimeout, diags := data.Timeouts.Delete(ctx, waiter.DefaultDeleteTimeout)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	hostID := data.ID.ValueString()
 	_, err := r.client.Delete(ctx, &fulfillmentv1.HostsDeleteRequest{
-		Id: data.ID.ValueString(),
+		Id: hostID,
+	})
+	if isNotFound(err) {
+		return
+	}
+	if err != nil {
+		resp.Diagnostics.AddError("Failed to delete host", operationErrorDetail("delete", "host", data.Name, hostID, err))
+		return
+	}
+
+	if !shouldWait(data.WaitForReady, r.wait) {
+		return
+	}
+
+	// Wait for the host to disappear
+	_, err = waiter.WaitForState(ctx, waiter.Config{
+		PendingStates: deletePendingStates(fulfillmentv1.HostState_name,
+			int32(fulfillmentv1.HostState_HOST_STATE_FAILED)),
+		TargetStates:      []string{waiter.DeletedState},
+		RefreshFunc:       r.hostDeleteRefreshFunc(ctx, hostID),
+		Timeout:           deleteTimeout,
+		FixedPollInterval: r.wait.PollInterval,
+		MinPollInterval:   r.wait.MinPollInterval,
+		BackoffMultiplier: r.wait.BackoffMultiplier,
+		MaxPollInterval:   r.wait.MaxPollInterval,
+		SlowWarningAfter:  r.wait.SlowWarningAfter,
 	})
 	if err != nil {
-		resp.Diagnostics.AddError("Failed to delete host", err.Error())
+		resp.Diagnostics.AddError(
+			"Error waiting for host to be deleted",
+			operationErrorDetail("delete", "host", data.Name, hostID, err),
+		)
 		return
 	}
 }
 
+// hostDeleteRefreshFunc returns a StateRefreshFunc used while the host is being deleted. It reports the
+// DeletedState pseudo state once the host isn't found, and an error including the reason if it fails.
+func (r *HostResource) hostDeleteRefreshFunc(ctx context.Context, hostID string) waiter.StateRefreshFunc {
+	return waiter.LogTransitions(ctx, "host", hostID, func() (interface{}, string, error) {
+		getResp, err := r.client.Get(ctx, &fulfillmentv1.HostsGetRequest{Id: hostID})
+		if isNotFound(err) {
+			return hostID, waiter.DeletedState, nil
+		}
+		if err != nil {
+			return nil, "", fmt.Errorf("failed to get host: %w", err)
+		}
+
+		host := getResp.Object
+		if host.Status == nil {
+			return host, fulfillmentv1.HostState_HOST_STATE_UNSPECIFIED.String(), nil
+		}
+
+		state := host.Status.State
+		if state == fulfillmentv1.HostState_HOST_STATE_FAILED {
+			return nil, state.String(), fmt.Errorf("host reached FAILED state while being deleted%s",
+				client.FailureReason(host.Status.Conditions))
+		}
+
+		return host, state.String(), nil
+	})
+}
+
+func (r *HostResource) ConfigValidators(ctx context.Context) []resource.ConfigValidator {
+	return []resource.ConfigValidator{
+		resourcevalidator.Conflicting(path.MatchRoot("name"), path.MatchRoot("name_prefix")),
+	}
+}
+
+func (r *HostResource) ModifyPlan(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	planClearableName(ctx, req, resp)
+}
+
 func (r *HostResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
-	resource.ImportStatePassthroughID(ctx, path.Root("id"), req, resp)
+	importState(ctx, req, resp, func(name string) (string, error) {
+		filter := client.NameFilter(name)
+		listResp, err := r.client.List(ctx, &fulfillmentv1.HostsListRequest{
+			Filter: &filter,
+		})
+		if err != nil {
+			return "", err
+		}
+		found, err := client.SelectByName("host", name, listResp.Items)
+		if err != nil {
+			return "", err
+		}
+		return found.Id, nil
+	})
+}
+
+// waitForPowerState waits till the observed power state of the host matches the desired one. Returns the host as
+// last read.
+func (r *HostResource) waitForPowerState(ctx context.Context, hostID string, desired fulfillmentv1.HostPowerState,
+	timeout time.Duration) (*fulfillmentv1.Host, error) {
+	var pending []string
+	for value, name := range fulfillmentv1.HostPowerState_name {
+		if value != int32(desired) {
+			pending = append(pending, name)
+		}
+	}
+	result, err := waiter.WaitForState(ctx, waiter.Config{
+		PendingStates:     pending,
+		TargetStates:      []string{desired.String()},
+		RefreshFunc:       r.hostPowerStateRefreshFunc(ctx, hostID),
+		Timeout:           timeout,
+		FixedPollInterval: r.wait.PollInterval,
+		MinPollInterval:   r.wait.MinPollInterval,
+		BackoffMultiplier: r.wait.BackoffMultiplier,
+		MaxPollInterval:   r.wait.MaxPollInterval,
+		SlowWarningAfter:  r.wait.SlowWarningAfter,
+	})
+	if err != nil {
+		return nil, err
+	}
+	return result.(*fulfillmentv1.Host), nil
+}
+
+// hostPowerStateRefreshFunc returns a StateRefreshFunc that fetches the host and returns its observed power state.
+func (r *HostResource) hostPowerStateRefreshFunc(ctx context.Context, hostID string) waiter.StateRefreshFunc {
+	return waiter.LogTransitions(ctx, "host", hostID, func() (interface{}, string, error) {
+		getResp, err := r.client.Get(ctx, &fulfillmentv1.HostsGetRequest{Id: hostID})
+		if err != nil {
+			return nil, "", fmt.Errorf("failed to get host: %w", err)
+		}
+
+		host := getResp.Object
+		if host.Status == nil {
+			return host, fulfillmentv1.HostPowerState_HOST_POWER_STATE_UNSPECIFIED.String(), nil
+		}
+
+		if host.Status.State == fulfillmentv1.HostState_HOST_STATE_FAILED {
+			return nil, host.Status.PowerState.String(), fmt.Errorf("host reached FAILED state%s", client.FailureReason(host.Status.Conditions))
+		}
+
+		return host, host.Status.PowerState.String(), nil
+	})
 }
 
 func (r *HostResource) updateModelFromHost(model *HostResourceModel, host *fulfillmentv1.Host) {
 	model.ID = types.StringValue(host.Id)
+	model.ShortID = types.StringValue(shortID(host.Id))
 
-	if host.Metadata != nil {
+	// An empty name is stored as null, so that it matches a name removed from the configuration
+	if host.Metadata != nil && host.Metadata.Name != "" {
 		model.Name = types.StringValue(host.Metadata.Name)
+	} else if host.Metadata != nil || model.Name.IsUnknown() {
+		model.Name = types.StringNull()
 	}
+	model.CreatedAt = client.CreatedAtValue(host.Metadata)
 
-	if host.Spec != nil {
-		model.PowerState = types.StringValue(host.Spec.PowerState.String())
-	}
-
+	// The desired power state is kept as configured, the observed one is reported in current_power_state.
 	if host.Status != nil {
 		model.State = types.StringValue(host.Status.State.String())
 		model.CurrentPowerState = types.StringValue(host.Status.PowerState.String())
+	} else {
+		model.State = types.StringNull()
+		model.CurrentPowerState = types.StringNull()
+	}
+}
+
+// driftedPowerState returns the desired power state to keep in the Terraform state. If the host was powered on or off
+// out of band, the power state of its spec or, when it isn't converging, the observed one is returned instead, so that
+// the next plan shows the difference with the configuration and Update applies it again. While the host is still
+// converging, for example after an update that didn't wait for it, the observed power state isn't a drift and the
+// desired one is kept.
+func driftedPowerState(desired types.String, host *fulfillmentv1.Host) types.String {
+	if desired.IsNull() || desired.IsUnknown() {
+		return desired
+	}
+	expected := parsePowerState(desired.ValueString())
+	if spec := host.GetSpec().GetPowerState(); spec != fulfillmentv1.HostPowerState_HOST_POWER_STATE_UNSPECIFIED &&
+		spec != expected {
+		return types.StringValue(formatPowerState(spec, desired.ValueString()))
+	}
+	if host.GetStatus().GetState() == fulfillmentv1.HostState_HOST_STATE_PROGRESSING {
+		return desired
+	}
+	if observed := host.GetStatus().GetPowerState(); observed != fulfillmentv1.HostPowerState_HOST_POWER_STATE_UNSPECIFIED &&
+		observed != expected {
+		return types.StringValue(formatPowerState(observed, desired.ValueString()))
+	}
+	return desired
+}
+
+// formatPowerState returns the name of the power state using the same notation as the reference value, either the
+// short form (ON) or the full enum name (HOST_POWER_STATE_ON).
+func formatPowerState(state fulfillmentv1.HostPowerState, reference string) string {
+	if strings.HasPrefix(reference, "HOST_POWER_STATE_") {
+		return state.String()
 	}
+	return strings.TrimPrefix(state.String(), "HOST_POWER_STATE_")
 }
 
 func parsePowerState(s string) fulfillmentv1.HostPowerState {
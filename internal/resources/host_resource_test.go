@@ -0,0 +1,228 @@
+/*
+Copyright (c) 2025 Red Hat Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License"); you may not use this file except in compliance with the
+License. You may obtain a copy of the License at
+
+  http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software distributed under the License is distributed on an
+"AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the License for the specific
+language governing permissions and limitations under the License.
+*/
+
+package resources
+
+import (
+	"context"
+	"slices"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+	"google.golang.org/grpc"
+
+	fulfillmentv1 "github.com/innabox/fulfillment-common/api/fulfillment/v1"
+	sharedv1 "github.com/innabox/fulfillment-common/api/shared/v1"
+)
+
+// fakeHostsClient is a hosts client that returns the given host from Get, and records the update requests, returning
+// their object. Other methods aren't implemented.
+type fakeHostsClient struct {
+	fulfillmentv1.HostsClient
+	host    *fulfillmentv1.Host
+	updates []*fulfillmentv1.HostsUpdateRequest
+}
+
+func (c *fakeHostsClient) Get(ctx context.Context, in *fulfillmentv1.HostsGetRequest,
+	opts ...grpc.CallOption) (*fulfillmentv1.HostsGetResponse, error) {
+	return &fulfillmentv1.HostsGetResponse{Object: c.host}, nil
+}
+
+func (c *fakeHostsClient) Update(ctx context.Context, in *fulfillmentv1.HostsUpdateRequest,
+	opts ...grpc.CallOption) (*fulfillmentv1.HostsUpdateResponse, error) {
+	c.updates = append(c.updates, in)
+	return &fulfillmentv1.HostsUpdateResponse{Object: in.Object}, nil
+}
+
+func TestDriftedPowerState(t *testing.T) {
+	host := func(spec, observed fulfillmentv1.HostPowerState, state fulfillmentv1.HostState) *fulfillmentv1.Host {
+		return &fulfillmentv1.Host{
+			Spec: &fulfillmentv1.HostSpec{
+				PowerState: spec,
+			},
+			Status: &fulfillmentv1.HostStatus{
+				State:      state,
+				PowerState: observed,
+			},
+		}
+	}
+	tests := []struct {
+		name     string
+		desired  types.String
+		host     *fulfillmentv1.Host
+		expected types.String
+	}{
+		{
+			name:    "In sync",
+			desired: types.StringValue("ON"),
+			host: host(fulfillmentv1.HostPowerState_HOST_POWER_STATE_ON, fulfillmentv1.HostPowerState_HOST_POWER_STATE_ON,
+				fulfillmentv1.HostState_HOST_STATE_READY),
+			expected: types.StringValue("ON"),
+		},
+		{
+			name:    "Powered off out of band",
+			desired: types.StringValue("ON"),
+			host: host(fulfillmentv1.HostPowerState_HOST_POWER_STATE_ON, fulfillmentv1.HostPowerState_HOST_POWER_STATE_OFF,
+				fulfillmentv1.HostState_HOST_STATE_READY),
+			expected: types.StringValue("OFF"),
+		},
+		{
+			name:    "Full enum name is kept",
+			desired: types.StringValue("HOST_POWER_STATE_ON"),
+			host: host(fulfillmentv1.HostPowerState_HOST_POWER_STATE_ON, fulfillmentv1.HostPowerState_HOST_POWER_STATE_OFF,
+				fulfillmentv1.HostState_HOST_STATE_READY),
+			expected: types.StringValue("HOST_POWER_STATE_OFF"),
+		},
+		{
+			name:    "Converging isn't a drift",
+			desired: types.StringValue("OFF"),
+			host: host(fulfillmentv1.HostPowerState_HOST_POWER_STATE_OFF, fulfillmentv1.HostPowerState_HOST_POWER_STATE_ON,
+				fulfillmentv1.HostState_HOST_STATE_PROGRESSING),
+			expected: types.StringValue("OFF"),
+		},
+		{
+			name:    "Spec changed out of band while converging",
+			desired: types.StringValue("ON"),
+			host: host(fulfillmentv1.HostPowerState_HOST_POWER_STATE_OFF, fulfillmentv1.HostPowerState_HOST_POWER_STATE_ON,
+				fulfillmentv1.HostState_HOST_STATE_PROGRESSING),
+			expected: types.StringValue("OFF"),
+		},
+		{
+			name:    "Unspecified observed power state",
+			desired: types.StringValue("ON"),
+			host: host(fulfillmentv1.HostPowerState_HOST_POWER_STATE_ON, fulfillmentv1.HostPowerState_HOST_POWER_STATE_UNSPECIFIED,
+				fulfillmentv1.HostState_HOST_STATE_READY),
+			expected: types.StringValue("ON"),
+		},
+		{
+			name:     "No status",
+			desired:  types.StringValue("ON"),
+			host:     &fulfillmentv1.Host{},
+			expected: types.StringValue("ON"),
+		},
+		{
+			name:    "Power state not managed",
+			desired: types.StringNull(),
+			host: host(fulfillmentv1.HostPowerState_HOST_POWER_STATE_ON, fulfillmentv1.HostPowerState_HOST_POWER_STATE_OFF,
+				fulfillmentv1.HostState_HOST_STATE_READY),
+			expected: types.StringNull(),
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			actual := driftedPowerState(test.desired, test.host)
+			if !actual.Equal(test.expected) {
+				t.Errorf("expected %s, got %s", test.expected, actual)
+			}
+		})
+	}
+}
+
+func TestHostUpdateMask(t *testing.T) {
+	value := func(v any) tftypes.Value {
+		switch v := v.(type) {
+		case bool:
+			return tftypes.NewValue(tftypes.Bool, v)
+		default:
+			return tftypes.NewValue(tftypes.String, v)
+		}
+	}
+	state := map[string]tftypes.Value{
+		"id":             value("my-host"),
+		"name":           value("my-host"),
+		"power_state":    value("OFF"),
+		"wait_for_ready": value(true),
+	}
+	tests := []struct {
+		name     string
+		changes  map[string]tftypes.Value
+		expected []string
+	}{
+		{
+			name: "Only wait_for_ready changed",
+			changes: map[string]tftypes.Value{
+				"wait_for_ready": value(false),
+			},
+		},
+		{
+			name: "Renamed",
+			changes: map[string]tftypes.Value{
+				"name":           value("new"),
+				"wait_for_ready": value(false),
+			},
+			expected: []string{"metadata.name"},
+		},
+		{
+			name: "Powered on",
+			changes: map[string]tftypes.Value{
+				"power_state":    value("ON"),
+				"wait_for_ready": value(false),
+			},
+			expected: []string{"spec.power_state"},
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			ctx := context.Background()
+			fake := &fakeHostsClient{
+				host: &fulfillmentv1.Host{
+					Id:       "my-host",
+					Metadata: &sharedv1.Metadata{Name: "my-host"},
+					Spec:     &fulfillmentv1.HostSpec{PowerState: fulfillmentv1.HostPowerState_HOST_POWER_STATE_OFF},
+				},
+			}
+			r := &HostResource{client: fake}
+			s := resourceSchema(t, r)
+			planned := map[string]tftypes.Value{}
+			for key, v := range state {
+				planned[key] = v
+			}
+			for key, v := range test.changes {
+				planned[key] = v
+			}
+			config := map[string]tftypes.Value{}
+			for key, v := range planned {
+				if key != "id" {
+					config[key] = v
+				}
+			}
+			req := resource.UpdateRequest{
+				Config: tfsdk.Config{Schema: s, Raw: objectValue(s, config)},
+				Plan:   tfsdk.Plan{Schema: s, Raw: objectValue(s, planned)},
+				State:  tfsdk.State{Schema: s, Raw: objectValue(s, state)},
+			}
+			resp := &resource.UpdateResponse{State: tfsdk.State{Schema: s, Raw: req.Plan.Raw}}
+			resp.Private = newPrivateState(resp.Private)
+
+			r.Update(ctx, req, resp)
+			if resp.Diagnostics.HasError() {
+				t.Fatalf("unexpected errors: %v", resp.Diagnostics)
+			}
+			if test.expected == nil {
+				if len(fake.updates) > 0 {
+					t.Errorf("expected no update, got mask %v", fake.updates[0].GetUpdateMask().GetPaths())
+				}
+				return
+			}
+			if len(fake.updates) != 1 {
+				t.Fatalf("expected one update, got %d", len(fake.updates))
+			}
+			if paths := fake.updates[0].GetUpdateMask().GetPaths(); !slices.Equal(paths, test.expected) {
+				t.Errorf("expected paths %v, got %v", test.expected, paths)
+			}
+		})
+	}
+}
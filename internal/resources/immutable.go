@@ -0,0 +1,72 @@
+/*
+Copyright (c) 2025 Red Hat Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License"); you may not use this file except in compliance with the
+License. You may obtain a copy of the License at
+
+  http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software distributed under the License is distributed on an
+"AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the License for the specific
+language governing permissions and limitations under the License.
+*/
+
+package resources
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+)
+
+// checkImmutableAttributes adds an error for each of the given attributes whose planned value differs from the value
+// in the state. The backend can't change these attributes, so rather than sending an update that would fail or be
+// silently ignored the user is told that the resource has to be replaced.
+func checkImmutableAttributes(ctx context.Context, req resource.UpdateRequest, diags *diag.Diagnostics, paths ...path.Path) {
+	for _, p := range paths {
+		var planned, current attr.Value
+		getDiags := req.Plan.GetAttribute(ctx, p, &planned)
+		getDiags.Append(req.State.GetAttribute(ctx, p, &current)...)
+		diags.Append(getDiags...)
+		if getDiags.HasError() {
+			return
+		}
+		if planned.IsUnknown() || planned.Equal(current) {
+			continue
+		}
+		diags.AddAttributeError(
+			p,
+			"Attribute can't be changed",
+			fmt.Sprintf(
+				"The value of '%s' can't be changed once the object has been created. Revert the change, or "+
+					"replace the resource with 'terraform apply -replace' to apply it.",
+				p,
+			),
+		)
+	}
+}
+
+// checkImmutableHostClasses adds an error for each node or host set that exists both in the state and in the plan
+// and whose host class changes. Sets can be added, removed and resized, but the host class of an existing set is
+// fixed. The maps go from set name to host class.
+func checkImmutableHostClasses(p path.Path, planned, current map[string]string, diags *diag.Diagnostics) {
+	for name, hostClass := range planned {
+		currentHostClass, ok := current[name]
+		if !ok || hostClass == currentHostClass {
+			continue
+		}
+		diags.AddAttributeError(
+			p,
+			"Host class can't be changed",
+			fmt.Sprintf(
+				"The host class of '%s' can't be changed from '%s' to '%s'. Revert the change, use a new "+
+					"name for the set, or replace the resource with 'terraform apply -replace'.",
+				name, currentHostClass, hostClass,
+			),
+		)
+	}
+}
@@ -0,0 +1,169 @@
+/*
+Copyright (c) 2025 Red Hat Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License"); you may not use this file except in compliance with the
+License. You may obtain a copy of the License at
+
+  http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software distributed under the License is distributed on an
+"AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the License for the specific
+language governing permissions and limitations under the License.
+*/
+
+package resources
+
+import (
+	"context"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+)
+
+func TestCheckImmutableAttributes(t *testing.T) {
+	resources := map[string]func() resource.Resource{
+		"Cluster":          NewClusterResource,
+		"Compute instance": NewComputeInstanceResource,
+	}
+	paths := []path.Path{
+		path.Root("template"),
+		path.Root("template_parameters"),
+		path.Root("template_parameters_json"),
+	}
+	parameters := func(value string) tftypes.Value {
+		return tftypes.NewValue(tftypes.Map{ElementType: tftypes.String}, map[string]tftypes.Value{
+			"version": tftypes.NewValue(tftypes.String, value),
+		})
+	}
+	state := map[string]tftypes.Value{
+		"id":                  tftypes.NewValue(tftypes.String, "my-object"),
+		"template":            tftypes.NewValue(tftypes.String, "small"),
+		"template_parameters": parameters("4.17"),
+	}
+	tests := []struct {
+		name     string
+		changes  map[string]tftypes.Value
+		expected []path.Path
+	}{
+		{
+			name: "Unchanged",
+		},
+		{
+			name: "Renamed",
+			changes: map[string]tftypes.Value{
+				"name": tftypes.NewValue(tftypes.String, "new"),
+			},
+		},
+		{
+			name: "Template changed",
+			changes: map[string]tftypes.Value{
+				"template": tftypes.NewValue(tftypes.String, "large"),
+			},
+			expected: []path.Path{path.Root("template")},
+		},
+		{
+			name: "Template parameters changed",
+			changes: map[string]tftypes.Value{
+				"template_parameters": parameters("4.18"),
+			},
+			expected: []path.Path{path.Root("template_parameters")},
+		},
+		{
+			name: "Template parameters moved to JSON",
+			changes: map[string]tftypes.Value{
+				"template_parameters":      tftypes.NewValue(tftypes.Map{ElementType: tftypes.String}, nil),
+				"template_parameters_json": tftypes.NewValue(tftypes.String, `{"version":"4.17"}`),
+			},
+			expected: []path.Path{path.Root("template_parameters"), path.Root("template_parameters_json")},
+		},
+		{
+			name: "Unknown template",
+			changes: map[string]tftypes.Value{
+				"template": tftypes.NewValue(tftypes.String, tftypes.UnknownValue),
+			},
+		},
+	}
+	for kind, newResource := range resources {
+		s := resourceSchema(t, newResource())
+		for _, test := range tests {
+			t.Run(kind+"/"+test.name, func(t *testing.T) {
+				planned := map[string]tftypes.Value{}
+				for key, value := range state {
+					planned[key] = value
+				}
+				for key, value := range test.changes {
+					planned[key] = value
+				}
+				req := resource.UpdateRequest{
+					Plan:  tfsdk.Plan{Schema: s, Raw: objectValue(s, planned)},
+					State: tfsdk.State{Schema: s, Raw: objectValue(s, state)},
+				}
+				resp := &resource.UpdateResponse{}
+
+				checkImmutableAttributes(context.Background(), req, &resp.Diagnostics, paths...)
+				if resp.Diagnostics.ErrorsCount() != len(test.expected) {
+					t.Fatalf("expected %d errors, got %v", len(test.expected), resp.Diagnostics)
+				}
+				for i, expected := range test.expected {
+					actual := resp.Diagnostics.Errors()[i].(diag.DiagnosticWithPath).Path()
+					if !actual.Equal(expected) {
+						t.Errorf("expected an error for %s, got one for %s", expected, actual)
+					}
+				}
+			})
+		}
+	}
+}
+
+func TestCheckImmutableHostClasses(t *testing.T) {
+	current := map[string]string{
+		"small": "acme_1tb",
+		"large": "acme_4tb",
+	}
+	tests := []struct {
+		name     string
+		planned  map[string]string
+		expected int
+	}{
+		{
+			name:    "Unchanged",
+			planned: current,
+		},
+		{
+			name: "Set added and set removed",
+			planned: map[string]string{
+				"small": "acme_1tb",
+				"gpu":   "acme_gpu",
+			},
+		},
+		{
+			name: "Host class changed",
+			planned: map[string]string{
+				"small": "acme_2tb",
+				"large": "acme_4tb",
+			},
+			expected: 1,
+		},
+		{
+			name: "Host classes swapped",
+			planned: map[string]string{
+				"small": "acme_4tb",
+				"large": "acme_1tb",
+			},
+			expected: 2,
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			var diags diag.Diagnostics
+			checkImmutableHostClasses(path.Root("host_sets"), test.planned, current, &diags)
+			if diags.ErrorsCount() != test.expected {
+				t.Errorf("expected %d errors, got %v", test.expected, diags)
+			}
+		})
+	}
+}
@@ -0,0 +1,63 @@
+/*
+Copyright (c) 2025 Red Hat Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License"); you may not use this file except in compliance with the
+License. You may obtain a copy of the License at
+
+  http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software distributed under the License is distributed on an
+"AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the License for the specific
+language governing permissions and limitations under the License.
+*/
+
+package resources
+
+import (
+	"context"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+)
+
+// importedPrivateStateKey is the private state key set by ImportState so that the first Read after an import knows
+// it has to reconstruct the attributes that are otherwise taken from the configuration.
+const importedPrivateStateKey = "imported"
+
+// importNamePrefix is the prefix of import identifiers that give the name of the object instead of its ID, like
+// name:my-cluster.
+const importNamePrefix = "name:"
+
+// importState imports the resource and marks the state as imported for the following Read. The import identifier is
+// used as the ID, unless it starts with importNamePrefix: then the rest is a name that the given function resolves to
+// the ID.
+func importState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse,
+	findID func(name string) (string, error)) {
+	if name, ok := strings.CutPrefix(req.ID, importNamePrefix); ok {
+		id, err := findID(name)
+		if err != nil {
+			resp.Diagnostics.AddError("Failed to import by name", err.Error())
+			return
+		}
+		resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("id"), id)...)
+	} else {
+		resource.ImportStatePassthroughID(ctx, path.Root("id"), req, resp)
+	}
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	resp.Diagnostics.Append(resp.Private.SetKey(ctx, importedPrivateStateKey, []byte("true"))...)
+}
+
+// isFirstReadAfterImport returns true if this is the first Read after the resource was imported, and clears the
+// mark so that following reads behave normally.
+func isFirstReadAfterImport(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) bool {
+	imported, diags := req.Private.GetKey(ctx, importedPrivateStateKey)
+	resp.Diagnostics.Append(diags...)
+	if len(imported) == 0 {
+		return false
+	}
+	resp.Diagnostics.Append(resp.Private.SetKey(ctx, importedPrivateStateKey, nil)...)
+	return true
+}
@@ -0,0 +1,111 @@
+/*
+Copyright (c) 2025 Red Hat Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License"); you may not use this file except in compliance with the
+License. You may obtain a copy of the License at
+
+  http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software distributed under the License is distributed on an
+"AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the License for the specific
+language governing permissions and limitations under the License.
+*/
+
+package resources
+
+import (
+	"context"
+	"crypto/rand"
+	"fmt"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// generatedNameSuffixLength is the number of random characters appended to a name prefix.
+const generatedNameSuffixLength = 8
+
+// configuredNamePrivateStateKey is the private state key set when the name of the object comes from the
+// configuration, so that planClearableName can tell it from a name assigned by the backend.
+const configuredNamePrivateStateKey = "configured_name"
+
+// privateState is the private state of the responses of Create and Update.
+type privateState interface {
+	SetKey(ctx context.Context, key string, value []byte) diag.Diagnostics
+}
+
+// generateName returns a name made of the given prefix and a random suffix, like "<prefix>-<suffix>".
+func generateName(prefix string) string {
+	suffix := strings.ToLower(rand.Text()[:generatedNameSuffixLength])
+	return fmt.Sprintf("%s-%s", prefix, suffix)
+}
+
+// planName computes the planned name of resources that support both name and name_prefix. A configured name is
+// used as is. Otherwise the name is left unknown when the object is going to be created, so that Create can
+// generate it from the prefix (or the backend can assign it), and kept from the state when the object is updated
+// in place.
+func planName(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	// Nothing to do when the resource is being destroyed
+	if req.Plan.Raw.IsNull() {
+		return
+	}
+
+	var configName types.String
+	resp.Diagnostics.Append(req.Config.GetAttribute(ctx, path.Root("name"), &configName)...)
+	if resp.Diagnostics.HasError() || !configName.IsNull() {
+		return
+	}
+
+	planned := types.StringUnknown()
+	if !req.State.Raw.IsNull() && len(resp.RequiresReplace) == 0 {
+		resp.Diagnostics.Append(req.State.GetAttribute(ctx, path.Root("name"), &planned)...)
+	}
+	resp.Diagnostics.Append(resp.Plan.SetAttribute(ctx, path.Root("name"), planned)...)
+}
+
+// planClearableName is like planName, for resources whose Update always sends the name. When neither name nor
+// name_prefix is configured, the object is updated in place and its name was set by the configuration, the name is
+// planned as null instead of being kept from the state, so that removing the name from the configuration clears it.
+// A name assigned by the backend, or read after an import, is kept.
+func planClearableName(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	planName(ctx, req, resp)
+	if resp.Diagnostics.HasError() || req.Plan.Raw.IsNull() || req.State.Raw.IsNull() || len(resp.RequiresReplace) > 0 {
+		return
+	}
+
+	var configName, configPrefix types.String
+	resp.Diagnostics.Append(req.Config.GetAttribute(ctx, path.Root("name"), &configName)...)
+	resp.Diagnostics.Append(req.Config.GetAttribute(ctx, path.Root("name_prefix"), &configPrefix)...)
+	if resp.Diagnostics.HasError() || !configName.IsNull() || !configPrefix.IsNull() {
+		return
+	}
+
+	configured, diags := req.Private.GetKey(ctx, configuredNamePrivateStateKey)
+	resp.Diagnostics.Append(diags...)
+	if len(configured) == 0 {
+		return
+	}
+	resp.Diagnostics.Append(resp.Plan.SetAttribute(ctx, path.Root("name"), types.StringNull())...)
+}
+
+// recordConfiguredName records in the private state whether the name is set in the configuration, for
+// planClearableName. Create and Update of the resources that use it call it with their configuration.
+func recordConfiguredName(ctx context.Context, config tfsdk.Config, private privateState) diag.Diagnostics {
+	var configName types.String
+	diags := config.GetAttribute(ctx, path.Root("name"), &configName)
+	if diags.HasError() {
+		return diags
+	}
+
+	// An empty value removes the key
+	var value []byte
+	if !configName.IsNull() {
+		value = []byte("true")
+	}
+	diags.Append(private.SetKey(ctx, configuredNamePrivateStateKey, value)...)
+	return diags
+}
@@ -0,0 +1,272 @@
+/*
+Copyright (c) 2025 Red Hat Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License"); you may not use this file except in compliance with the
+License. You may obtain a copy of the License at
+
+  http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software distributed under the License is distributed on an
+"AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the License for the specific
+language governing permissions and limitations under the License.
+*/
+
+package resources
+
+import (
+	"context"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+)
+
+// resourceSchema returns the schema of the given resource.
+func resourceSchema(t *testing.T, r resource.Resource) schema.Schema {
+	t.Helper()
+	resp := &resource.SchemaResponse{}
+	r.Schema(context.Background(), resource.SchemaRequest{}, resp)
+	if resp.Diagnostics.HasError() {
+		t.Fatalf("unexpected schema errors: %v", resp.Diagnostics)
+	}
+	return resp.Schema
+}
+
+// objectValue returns the raw value of an object of the given schema with the given attribute values. The other
+// attributes are null.
+func objectValue(s schema.Schema, values map[string]tftypes.Value) tftypes.Value {
+	objectType := s.Type().TerraformType(context.Background()).(tftypes.Object)
+	attributes := make(map[string]tftypes.Value, len(objectType.AttributeTypes))
+	for name, attributeType := range objectType.AttributeTypes {
+		if value, ok := values[name]; ok {
+			attributes[name] = value
+		} else {
+			attributes[name] = tftypes.NewValue(attributeType, nil)
+		}
+	}
+	return tftypes.NewValue(objectType, attributes)
+}
+
+// newPrivateState returns empty private state data, of the type of the given one. The type belongs to an internal
+// package of the framework, so it can't be named here.
+func newPrivateState[T any](*T) *T {
+	return new(T)
+}
+
+func TestNameConflictsWithNamePrefix(t *testing.T) {
+	resources := map[string]func() resource.Resource{
+		"Cluster":          NewClusterResource,
+		"Compute instance": NewComputeInstanceResource,
+		"Host":             NewHostResource,
+		"Host pool":        NewHostPoolResource,
+	}
+	tests := []struct {
+		name     string
+		values   map[string]tftypes.Value
+		conflict bool
+	}{
+		{
+			name: "Name only",
+			values: map[string]tftypes.Value{
+				"name": tftypes.NewValue(tftypes.String, "my-object"),
+			},
+		},
+		{
+			name: "Name prefix only",
+			values: map[string]tftypes.Value{
+				"name_prefix": tftypes.NewValue(tftypes.String, "my"),
+			},
+		},
+		{
+			name:   "Neither",
+			values: map[string]tftypes.Value{},
+		},
+		{
+			name: "Both",
+			values: map[string]tftypes.Value{
+				"name":        tftypes.NewValue(tftypes.String, "my-object"),
+				"name_prefix": tftypes.NewValue(tftypes.String, "my"),
+			},
+			conflict: true,
+		},
+	}
+	for kind, newResource := range resources {
+		r := newResource()
+		s := resourceSchema(t, r)
+		validators := r.(resource.ResourceWithConfigValidators).ConfigValidators(context.Background())
+		for _, test := range tests {
+			t.Run(kind+"/"+test.name, func(t *testing.T) {
+				req := resource.ValidateConfigRequest{
+					Config: tfsdk.Config{
+						Schema: s,
+						Raw:    objectValue(s, test.values),
+					},
+				}
+				// Like the framework, give each validator its own response, as some replace the diagnostics
+				var diags diag.Diagnostics
+				for _, v := range validators {
+					resp := &resource.ValidateConfigResponse{}
+					v.ValidateResource(context.Background(), req, resp)
+					diags.Append(resp.Diagnostics...)
+				}
+				if diags.HasError() != test.conflict {
+					t.Errorf("expected conflict %t, got diagnostics %v", test.conflict, diags)
+				}
+			})
+		}
+	}
+}
+
+func TestPlanClearableName(t *testing.T) {
+	resources := map[string]func() resource.Resource{
+		"Host":      NewHostResource,
+		"Host pool": NewHostPoolResource,
+	}
+	name := func(value any) map[string]tftypes.Value {
+		return map[string]tftypes.Value{
+			"name": tftypes.NewValue(tftypes.String, value),
+		}
+	}
+	tests := []struct {
+		name       string
+		config     map[string]tftypes.Value
+		state      map[string]tftypes.Value
+		configured bool
+		replace    bool
+		expected   types.String
+	}{
+		{
+			name:     "Configured name",
+			config:   name("new"),
+			state:    name("old"),
+			expected: types.StringValue("new"),
+		},
+		{
+			name:       "Removed name is cleared",
+			config:     map[string]tftypes.Value{},
+			state:      name("old"),
+			configured: true,
+			expected:   types.StringNull(),
+		},
+		{
+			name:     "Name assigned by the backend is kept",
+			config:   map[string]tftypes.Value{},
+			state:    name("assigned"),
+			expected: types.StringValue("assigned"),
+		},
+		{
+			name: "Name generated from a prefix is kept",
+			config: map[string]tftypes.Value{
+				"name_prefix": tftypes.NewValue(tftypes.String, "my"),
+			},
+			state:    name("my-abcdefgh"),
+			expected: types.StringValue("my-abcdefgh"),
+		},
+		{
+			name:     "Name is unknown on create",
+			config:   map[string]tftypes.Value{},
+			expected: types.StringUnknown(),
+		},
+		{
+			name:       "Name is unknown on replace",
+			config:     map[string]tftypes.Value{},
+			state:      name("old"),
+			configured: true,
+			replace:    true,
+			expected:   types.StringUnknown(),
+		},
+	}
+	for kind, newResource := range resources {
+		s := resourceSchema(t, newResource())
+		for _, test := range tests {
+			t.Run(kind+"/"+test.name, func(t *testing.T) {
+				// Like the framework, plan the configured values and leave the computed name unknown
+				planned := map[string]tftypes.Value{
+					"name": tftypes.NewValue(tftypes.String, tftypes.UnknownValue),
+				}
+				for key, value := range test.config {
+					planned[key] = value
+				}
+				state := tftypes.NewValue(s.Type().TerraformType(context.Background()), nil)
+				if test.state != nil {
+					state = objectValue(s, test.state)
+				}
+				req := resource.ModifyPlanRequest{
+					Config: tfsdk.Config{Schema: s, Raw: objectValue(s, test.config)},
+					State:  tfsdk.State{Schema: s, Raw: state},
+					Plan:   tfsdk.Plan{Schema: s, Raw: objectValue(s, planned)},
+				}
+				if test.configured {
+					req.Private = newPrivateState(req.Private)
+					diags := req.Private.SetKey(context.Background(), configuredNamePrivateStateKey, []byte("true"))
+					if diags.HasError() {
+						t.Fatalf("unexpected errors: %v", diags)
+					}
+				}
+				resp := &resource.ModifyPlanResponse{Plan: req.Plan}
+				if test.replace {
+					resp.RequiresReplace = append(resp.RequiresReplace, path.Root("name_prefix"))
+				}
+
+				planClearableName(context.Background(), req, resp)
+				if resp.Diagnostics.HasError() {
+					t.Fatalf("unexpected errors: %v", resp.Diagnostics)
+				}
+				var actual types.String
+				resp.Diagnostics.Append(resp.Plan.GetAttribute(context.Background(), path.Root("name"), &actual)...)
+				if !actual.Equal(test.expected) {
+					t.Errorf("expected %s, got %s", test.expected, actual)
+				}
+			})
+		}
+	}
+}
+
+func TestRecordConfiguredName(t *testing.T) {
+	s := resourceSchema(t, NewHostResource())
+	tests := []struct {
+		name       string
+		config     map[string]tftypes.Value
+		configured bool
+	}{
+		{
+			name: "Configured name",
+			config: map[string]tftypes.Value{
+				"name": tftypes.NewValue(tftypes.String, "my-host"),
+			},
+			configured: true,
+		},
+		{
+			name: "Name generated from a prefix",
+			config: map[string]tftypes.Value{
+				"name_prefix": tftypes.NewValue(tftypes.String, "my"),
+			},
+		},
+		{
+			name:   "No name",
+			config: map[string]tftypes.Value{},
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			// Start from a name recorded before, which has to be removed when it is no longer configured
+			resp := &resource.UpdateResponse{}
+			resp.Private = newPrivateState(resp.Private)
+			diags := resp.Private.SetKey(context.Background(), configuredNamePrivateStateKey, []byte("true"))
+			config := tfsdk.Config{Schema: s, Raw: objectValue(s, test.config)}
+			diags.Append(recordConfiguredName(context.Background(), config, resp.Private)...)
+			if diags.HasError() {
+				t.Fatalf("unexpected errors: %v", diags)
+			}
+			value, _ := resp.Private.GetKey(context.Background(), configuredNamePrivateStateKey)
+			if configured := len(value) > 0; configured != test.configured {
+				t.Errorf("expected configured %t, got %t", test.configured, configured)
+			}
+		})
+	}
+}
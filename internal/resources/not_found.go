@@ -0,0 +1,44 @@
+/*
+Copyright (c) 2025 Red Hat Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License"); you may not use this file except in compliance with the
+License. You may obtain a copy of the License at
+
+  http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software distributed under the License is distributed on an
+"AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the License for the specific
+language governing permissions and limitations under the License.
+*/
+
+package resources
+
+import (
+	"context"
+
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// isNotFound checks if the error returned by the API means that the object doesn't exist.
+func isNotFound(err error) bool {
+	return status.Code(err) == codes.NotFound
+}
+
+// removeIfNotFound removes the resource from the state when the error returned by the API while reading it means
+// that it no longer exists, so that the next plan proposes to create it again instead of failing. Returns true if
+// the resource was removed.
+func removeIfNotFound(ctx context.Context, err error, kind string, id types.String, resp *resource.ReadResponse) bool {
+	if !isNotFound(err) {
+		return false
+	}
+	tflog.Warn(ctx, "Object no longer exists, removing it from the state", map[string]interface{}{
+		"kind": kind,
+		"id":   id.ValueString(),
+	})
+	resp.State.RemoveResource(ctx)
+	return true
+}
@@ -0,0 +1,91 @@
+/*
+Copyright (c) 2025 Red Hat Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License"); you may not use this file except in compliance with the
+License. You may obtain a copy of the License at
+
+  http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software distributed under the License is distributed on an
+"AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the License for the specific
+language governing permissions and limitations under the License.
+*/
+
+package resources
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// configuredSets returns the node or host sets reported by the backend that are managed by Terraform. When current,
+// the value of the plan or of the state, is a known map, the sets that the backend added on its own are left out so
+// that they don't show up as a perpetual diff. Otherwise, for example after an import, all the sets are returned.
+func configuredSets[T any](current types.Map, sets map[string]T) map[string]T {
+	if current.IsNull() || current.IsUnknown() {
+		return sets
+	}
+	configured := current.Elements()
+	result := make(map[string]T, len(configured))
+	for name, set := range sets {
+		if _, ok := configured[name]; ok {
+			result[name] = set
+		}
+	}
+	return result
+}
+
+// withBackendSets returns the node or host sets to send in an update, which replaces the whole map on the server: the
+// configured sets, plus the sets of the object on the server that the backend added on its own. Those are the sets
+// that aren't in previous, the sets of the state, which configuredSets left them out of. A set removed from the
+// configuration is in previous, so it is left out and the update deletes it.
+func withBackendSets[T, P any](sets, server map[string]T, previous map[string]P) map[string]T {
+	result := make(map[string]T, len(sets))
+	for name, set := range server {
+		if _, ok := previous[name]; !ok {
+			result[name] = set
+		}
+	}
+	for name, set := range sets {
+		result[name] = set
+	}
+	return result
+}
+
+// useStateForUnknownUnlessChanged returns a plan modifier that keeps the value of a computed map from the state, like
+// UseStateForUnknown, as long as the attribute it is derived from doesn't change. When it changes, the value is left
+// unknown so that it is read again after the apply.
+func useStateForUnknownUnlessChanged(source path.Path) planmodifier.Map {
+	return useStateForUnknownUnlessChangedModifier{source: source}
+}
+
+type useStateForUnknownUnlessChangedModifier struct {
+	source path.Path
+}
+
+func (m useStateForUnknownUnlessChangedModifier) Description(ctx context.Context) string {
+	return fmt.Sprintf("The value is kept from the state unless '%s' changes.", m.source)
+}
+
+func (m useStateForUnknownUnlessChangedModifier) MarkdownDescription(ctx context.Context) string {
+	return m.Description(ctx)
+}
+
+func (m useStateForUnknownUnlessChangedModifier) PlanModifyMap(ctx context.Context, req planmodifier.MapRequest, resp *planmodifier.MapResponse) {
+	if req.StateValue.IsNull() || !req.PlanValue.IsUnknown() || req.ConfigValue.IsUnknown() || !req.ConfigValue.IsNull() {
+		return
+	}
+
+	var planSource, stateSource attr.Value
+	resp.Diagnostics.Append(req.Plan.GetAttribute(ctx, m.source, &planSource)...)
+	resp.Diagnostics.Append(req.State.GetAttribute(ctx, m.source, &stateSource)...)
+	if resp.Diagnostics.HasError() || !planSource.Equal(stateSource) {
+		return
+	}
+	resp.PlanValue = req.StateValue
+}
@@ -0,0 +1,66 @@
+/*
+Copyright (c) 2025 Red Hat Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License"); you may not use this file except in compliance with the
+License. You may obtain a copy of the License at
+
+  http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software distributed under the License is distributed on an
+"AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the License for the specific
+language governing permissions and limitations under the License.
+*/
+
+package resources
+
+import (
+	"maps"
+	"testing"
+)
+
+func TestWithBackendSets(t *testing.T) {
+	tests := []struct {
+		name     string
+		sets     map[string]int
+		server   map[string]int
+		previous map[string]int
+		expected map[string]int
+	}{
+		{
+			name:     "Sets added by the backend are kept",
+			sets:     map[string]int{"workers": 3},
+			server:   map[string]int{"workers": 2, "infra": 1},
+			previous: map[string]int{"workers": 2},
+			expected: map[string]int{"workers": 3, "infra": 1},
+		},
+		{
+			name:     "Sets removed from the configuration are deleted",
+			sets:     map[string]int{"workers": 2},
+			server:   map[string]int{"workers": 2, "gpu": 1},
+			previous: map[string]int{"workers": 2, "gpu": 1},
+			expected: map[string]int{"workers": 2},
+		},
+		{
+			name:     "Configured sets override those of the server",
+			sets:     map[string]int{"workers": 5},
+			server:   map[string]int{"workers": 2},
+			previous: map[string]int{},
+			expected: map[string]int{"workers": 5},
+		},
+		{
+			name:     "Sets of a new configuration",
+			sets:     map[string]int{"workers": 2},
+			server:   nil,
+			previous: nil,
+			expected: map[string]int{"workers": 2},
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			actual := withBackendSets(test.sets, test.server, test.previous)
+			if !maps.Equal(actual, test.expected) {
+				t.Errorf("expected %v, got %v", test.expected, actual)
+			}
+		})
+	}
+}
@@ -0,0 +1,26 @@
+/*
+Copyright (c) 2025 Red Hat Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License"); you may not use this file except in compliance with the
+License. You may obtain a copy of the License at
+
+  http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software distributed under the License is distributed on an
+"AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the License for the specific
+language governing permissions and limitations under the License.
+*/
+
+package resources
+
+// shortIDLength is the number of characters of the identifier kept in the short_id attribute.
+const shortIDLength = 8
+
+// shortID returns the short form of an identifier used for the short_id attribute: its first characters, or the
+// whole identifier when it is shorter. It only depends on the identifier, so it is stable across refreshes.
+func shortID(id string) string {
+	if len(id) <= shortIDLength {
+		return id
+	}
+	return id[:shortIDLength]
+}
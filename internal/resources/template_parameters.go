@@ -0,0 +1,186 @@
+/*
+Copyright (c) 2025 Red Hat Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License"); you may not use this file except in compliance with the
+License. You may obtain a copy of the License at
+
+  http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software distributed under the License is distributed on an
+"AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the License for the specific
+language governing permissions and limitations under the License.
+*/
+
+package resources
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"slices"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/mapplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"google.golang.org/protobuf/proto"
+)
+
+// templateParameterDefinition is implemented by the parameter definitions of both cluster and compute instance
+// templates.
+type templateParameterDefinition interface {
+	GetName() string
+	GetRequired() bool
+}
+
+// templateParametersChanged checks if the plan creates the resource or changes its template or template parameters.
+// Those are the only cases where the parameters need to be checked against the template.
+func templateParametersChanged(ctx context.Context, req resource.ModifyPlanRequest, diags *diag.Diagnostics) bool {
+	if req.State.Raw.IsNull() {
+		return true
+	}
+	for _, name := range []string{"template", "template_parameters", "template_parameters_json"} {
+		var planned, current attr.Value
+		diags.Append(req.Plan.GetAttribute(ctx, path.Root(name), &planned)...)
+		diags.Append(req.State.GetAttribute(ctx, path.Root(name), &current)...)
+		if diags.HasError() {
+			return false
+		}
+		if !planned.Equal(current) {
+			return true
+		}
+	}
+	return false
+}
+
+// templateParametersReplaceDescription describes the plan modifiers returned by templateParametersRequiresReplace and
+// templateParametersJSONRequiresReplace.
+const templateParametersReplaceDescription = "Changing the template parameters forces a new resource. Moving the " +
+	"same values between template_parameters and template_parameters_json doesn't."
+
+// templateParametersRequiresReplace returns the plan modifier of the template_parameters attribute.
+func templateParametersRequiresReplace() planmodifier.Map {
+	return mapplanmodifier.RequiresReplaceIf(
+		func(ctx context.Context, req planmodifier.MapRequest, resp *mapplanmodifier.RequiresReplaceIfFuncResponse) {
+			resp.RequiresReplace = templateParametersDiffer(ctx, req.Plan, req.State, &resp.Diagnostics)
+		},
+		templateParametersReplaceDescription,
+		templateParametersReplaceDescription,
+	)
+}
+
+// templateParametersJSONRequiresReplace returns the plan modifier of the template_parameters_json attribute.
+func templateParametersJSONRequiresReplace() planmodifier.String {
+	return stringplanmodifier.RequiresReplaceIf(
+		func(ctx context.Context, req planmodifier.StringRequest, resp *stringplanmodifier.RequiresReplaceIfFuncResponse) {
+			resp.RequiresReplace = templateParametersDiffer(ctx, req.Plan, req.State, &resp.Diagnostics)
+		},
+		templateParametersReplaceDescription,
+		templateParametersReplaceDescription,
+	)
+}
+
+// templateParametersDiffer checks if the plan changes the template parameters sent to the server. The same parameters
+// can be given either as a map or as JSON, for example when the configuration uses the JSON form for parameters that
+// an import read back as a map, and moving them from one attribute to the other doesn't change them. Unknown values
+// are assumed to change.
+func templateParametersDiffer(ctx context.Context, plan tfsdk.Plan, state tfsdk.State, diags *diag.Diagnostics) bool {
+	var plannedMap, currentMap types.Map
+	var plannedJSON, currentJSON types.String
+	diags.Append(plan.GetAttribute(ctx, path.Root("template_parameters"), &plannedMap)...)
+	diags.Append(plan.GetAttribute(ctx, path.Root("template_parameters_json"), &plannedJSON)...)
+	diags.Append(state.GetAttribute(ctx, path.Root("template_parameters"), &currentMap)...)
+	diags.Append(state.GetAttribute(ctx, path.Root("template_parameters_json"), &currentJSON)...)
+	if diags.HasError() {
+		return false
+	}
+	if plannedMap.IsUnknown() || plannedJSON.IsUnknown() {
+		return true
+	}
+
+	planned, err := buildTemplateParameters(ctx, plannedMap, plannedJSON)
+	if err != nil {
+		return true
+	}
+	current, err := buildTemplateParameters(ctx, currentMap, currentJSON)
+	if err != nil {
+		return true
+	}
+	if len(planned) != len(current) {
+		return true
+	}
+	for key, value := range planned {
+		if !proto.Equal(value, current[key]) {
+			return true
+		}
+	}
+	return false
+}
+
+// checkTemplateParameters adds an error for each parameter of the plan that the template doesn't accept, and for each
+// required parameter of the template that the plan doesn't give. Nothing is checked while the parameters are unknown.
+func checkTemplateParameters[T templateParameterDefinition](ctx context.Context, params types.Map, paramsJSON types.String,
+	definitions []T, diags *diag.Diagnostics) {
+	if params.IsUnknown() || paramsJSON.IsUnknown() {
+		return
+	}
+
+	// Collect the names of the given parameters, and where each of them is configured
+	attribute := path.Root("template_parameters")
+	given := make(map[string]path.Path)
+	switch {
+	case !paramsJSON.IsNull():
+		attribute = path.Root("template_parameters_json")
+		var values map[string]json.RawMessage
+		if err := json.Unmarshal([]byte(paramsJSON.ValueString()), &values); err != nil {
+			// Invalid JSON is reported when the configuration is validated
+			return
+		}
+		for name := range values {
+			given[name] = attribute
+		}
+	case !params.IsNull():
+		values := make(map[string]types.String)
+		diags.Append(params.ElementsAs(ctx, &values, false)...)
+		if diags.HasError() {
+			return
+		}
+		for name := range values {
+			given[name] = attribute.AtMapKey(name)
+		}
+	}
+
+	accepted := make(map[string]bool)
+	for _, definition := range definitions {
+		accepted[definition.GetName()] = true
+		if _, ok := given[definition.GetName()]; definition.GetRequired() && !ok {
+			diags.AddAttributeError(
+				attribute,
+				"Missing template parameter",
+				fmt.Sprintf("The template requires the parameter '%s', but it isn't given.", definition.GetName()),
+			)
+		}
+	}
+
+	// Report the unknown parameters in a stable order
+	names := make([]string, 0, len(given))
+	for name := range given {
+		names = append(names, name)
+	}
+	slices.Sort(names)
+	for _, name := range names {
+		if accepted[name] {
+			continue
+		}
+		diags.AddAttributeError(
+			given[name],
+			"Unknown template parameter",
+			fmt.Sprintf("The template doesn't accept a parameter named '%s'.", name),
+		)
+	}
+}
@@ -0,0 +1,38 @@
+/*
+Copyright (c) 2025 Red Hat Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License"); you may not use this file except in compliance with the
+License. You may obtain a copy of the License at
+
+  http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software distributed under the License is distributed on an
+"AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the License for the specific
+language governing permissions and limitations under the License.
+*/
+
+package resources
+
+import (
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"google.golang.org/protobuf/types/known/fieldmaskpb"
+)
+
+// updateMask returns the field mask of an update request. It lists only the fields that the provider manages and
+// that the update changes, so that the server leaves the other fields of the object alone, including the fields added
+// to the API after this version of the provider. The name is listed when the planned name is known and differs from
+// the state, or when it's removed and clearName is true, so that removing it from the configuration clears it. The
+// spec paths are those of the parts of the spec that changed. When nothing changed, for example because only
+// attributes of the provider like wait_for_ready or the timeouts did, it returns nil: the update must then be skipped,
+// as an empty mask would ask the server to replace the whole object.
+func updateMask(clearName bool, name, stateName types.String, specPaths ...string) *fieldmaskpb.FieldMask {
+	var paths []string
+	if !name.Equal(stateName) && (clearName || (!name.IsNull() && !name.IsUnknown())) {
+		paths = append(paths, "metadata.name")
+	}
+	paths = append(paths, specPaths...)
+	if len(paths) == 0 {
+		return nil
+	}
+	return &fieldmaskpb.FieldMask{Paths: paths}
+}
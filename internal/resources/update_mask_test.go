@@ -0,0 +1,114 @@
+/*
+Copyright (c) 2025 Red Hat Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License"); you may not use this file except in compliance with the
+License. You may obtain a copy of the License at
+
+  http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software distributed under the License is distributed on an
+"AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the License for the specific
+language governing permissions and limitations under the License.
+*/
+
+package resources
+
+import (
+	"slices"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+func TestUpdateMask(t *testing.T) {
+	tests := []struct {
+		name      string
+		clearName bool
+		value     types.String
+		state     types.String
+		specPaths []string
+		expected  []string
+	}{
+		{
+			name:     "Renamed",
+			value:    types.StringValue("my-cluster"),
+			state:    types.StringValue("old"),
+			expected: []string{"metadata.name"},
+		},
+		{
+			name:     "Named",
+			value:    types.StringValue("my-cluster"),
+			state:    types.StringNull(),
+			expected: []string{"metadata.name"},
+		},
+		{
+			name:      "Unchanged name isn't sent",
+			clearName: true,
+			value:     types.StringValue("my-pool"),
+			state:     types.StringValue("my-pool"),
+			specPaths: []string{"spec.host_sets"},
+			expected:  []string{"spec.host_sets"},
+		},
+		{
+			name:     "Null name isn't cleared",
+			value:    types.StringNull(),
+			state:    types.StringValue("old"),
+			expected: nil,
+		},
+		{
+			name:     "Unknown name isn't sent",
+			value:    types.StringUnknown(),
+			state:    types.StringValue("old"),
+			expected: nil,
+		},
+		{
+			name:      "Null name is cleared",
+			clearName: true,
+			value:     types.StringNull(),
+			state:     types.StringValue("old"),
+			expected:  []string{"metadata.name"},
+		},
+		{
+			name:      "Spec paths follow the name",
+			clearName: true,
+			value:     types.StringValue("my-pool"),
+			state:     types.StringValue("old"),
+			specPaths: []string{"spec.host_sets"},
+			expected:  []string{"metadata.name", "spec.host_sets"},
+		},
+		{
+			name:      "Only spec paths",
+			value:     types.StringNull(),
+			state:     types.StringNull(),
+			specPaths: []string{"spec.node_sets"},
+			expected:  []string{"spec.node_sets"},
+		},
+		{
+			name:     "Nothing changed",
+			value:    types.StringNull(),
+			state:    types.StringNull(),
+			expected: nil,
+		},
+		{
+			name:      "Nothing changed with a clearable name",
+			clearName: true,
+			value:     types.StringValue("my-host"),
+			state:     types.StringValue("my-host"),
+			expected:  nil,
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			mask := updateMask(test.clearName, test.value, test.state, test.specPaths...)
+			if test.expected == nil {
+				if mask != nil {
+					t.Errorf("expected no field mask, got paths %v", mask.GetPaths())
+				}
+				return
+			}
+			if !slices.Equal(mask.GetPaths(), test.expected) {
+				t.Errorf("expected paths %v, got %v", test.expected, mask.GetPaths())
+			}
+		})
+	}
+}
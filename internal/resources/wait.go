@@ -0,0 +1,42 @@
+/*
+Copyright (c) 2025 Red Hat Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License"); you may not use this file except in compliance with the
+License. You may obtain a copy of the License at
+
+  http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software distributed under the License is distributed on an
+"AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the License for the specific
+language governing permissions and limitations under the License.
+*/
+
+package resources
+
+import (
+	"github.com/hashicorp/terraform-plugin-framework/types"
+
+	"github.com/innabox/terraform-provider-osac/internal/client"
+)
+
+// shouldWait checks if Create and Update wait for the object to become ready. The wait_for_ready attribute of the
+// resource, when set, takes precedence over the provider-wide setting.
+func shouldWait(waitForReady types.Bool, settings client.WaitSettings) bool {
+	if !waitForReady.IsNull() && !waitForReady.IsUnknown() {
+		return waitForReady.ValueBool()
+	}
+	return !settings.Disabled
+}
+
+// deletePendingStates returns the names of the states of an enum that are considered pending while an object is being
+// deleted. Whatever state the object reports meanwhile, including a deleting state, is pending, except the failed
+// one.
+func deletePendingStates(names map[int32]string, failed int32) []string {
+	var pending []string
+	for value, name := range names {
+		if value != failed {
+			pending = append(pending, name)
+		}
+	}
+	return pending
+}
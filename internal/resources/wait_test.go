@@ -0,0 +1,34 @@
+/*
+Copyright (c) 2025 Red Hat Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License"); you may not use this file except in compliance with the
+License. You may obtain a copy of the License at
+
+  http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software distributed under the License is distributed on an
+"AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the License for the specific
+language governing permissions and limitations under the License.
+*/
+
+package resources
+
+import (
+	"slices"
+	"testing"
+
+	fulfillmentv1 "github.com/innabox/fulfillment-common/api/fulfillment/v1"
+)
+
+func TestDeletePendingStates(t *testing.T) {
+	pending := deletePendingStates(fulfillmentv1.ClusterState_name, int32(fulfillmentv1.ClusterState_CLUSTER_STATE_FAILED))
+	slices.Sort(pending)
+	expected := []string{
+		"CLUSTER_STATE_PROGRESSING",
+		"CLUSTER_STATE_READY",
+		"CLUSTER_STATE_UNSPECIFIED",
+	}
+	if !slices.Equal(pending, expected) {
+		t.Errorf("expected pending states %v, got %v", expected, pending)
+	}
+}
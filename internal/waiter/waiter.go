@@ -0,0 +1,312 @@
+/*
+Copyright (c) 2025 Red Hat Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License"); you may not use this file except in compliance with the
+License. You may obtain a copy of the License at
+
+  http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software distributed under the License is distributed on an
+"AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the License for the specific
+language governing permissions and limitations under the License.
+*/
+
+// Package waiter contains the logic used by resources and data sources to poll objects until they reach a desired
+// state.
+package waiter
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math/rand/v2"
+	"slices"
+	"strings"
+	"sync/atomic"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/retry"
+)
+
+const (
+	// DefaultCreateTimeout is the default timeout for creating resources
+	DefaultCreateTimeout = 30 * time.Minute
+	// DefaultUpdateTimeout is the default timeout for updating resources
+	DefaultUpdateTimeout = 30 * time.Minute
+	// DefaultDeleteTimeout is the default timeout for deleting resources
+	DefaultDeleteTimeout = 30 * time.Minute
+	// DefaultReadTimeout is the default timeout for data sources that wait for objects to be ready
+	DefaultReadTimeout = 30 * time.Minute
+	// DefaultPowerStateTimeout is the default timeout for hosts to reach the desired power state
+	DefaultPowerStateTimeout = 10 * time.Minute
+	// DefaultPollInterval is the polling interval for checking resource status
+	DefaultPollInterval = 10 * time.Second
+	// DefaultMinPollInterval is the minimum polling interval
+	DefaultMinPollInterval = 5 * time.Second
+	// MinAllowedPollInterval is the shortest polling interval that users can configure
+	MinAllowedPollInterval = time.Second
+	// MaxAllowedPollInterval is the bound, excluded, of the polling intervals that users can configure. The state
+	// change loop ignores fixed intervals of this length or more.
+	MaxAllowedPollInterval = 3 * time.Minute
+	// MaxMinPollInterval is the longest minimum polling interval that users can configure. The backoff of the
+	// state change loop never waits longer than this between polls, so a longer minimum wouldn't be honored.
+	MaxMinPollInterval = 10 * time.Second
+	// DefaultMaxPollInterval is the longest polling interval reached when backoff is enabled
+	DefaultMaxPollInterval = 2 * time.Minute
+	// MaxBackoffMultiplier is the largest backoff multiplier that users can configure
+	MaxBackoffMultiplier = 10.0
+)
+
+// ParsePollInterval parses a polling interval configured by the user, like "30s". It must be at least
+// MinAllowedPollInterval, so that waiting doesn't flood the API with requests, and less than MaxAllowedPollInterval,
+// so that it isn't silently ignored.
+func ParsePollInterval(value string) (time.Duration, error) {
+	interval, err := time.ParseDuration(value)
+	if err != nil {
+		return 0, fmt.Errorf("'%s' isn't a valid duration: %w", value, err)
+	}
+	if interval < MinAllowedPollInterval {
+		return 0, fmt.Errorf("poll interval must be at least %s, but it is %s", MinAllowedPollInterval, interval)
+	}
+	if interval >= MaxAllowedPollInterval {
+		return 0, fmt.Errorf("poll interval must be less than %s, but it is %s", MaxAllowedPollInterval, interval)
+	}
+	return interval, nil
+}
+
+// DeletedState is the pseudo state returned by refresh functions once the object doesn't exist anymore, so that
+// deletion can be waited for like any other state.
+const DeletedState = "DELETED"
+
+// ErrCancelled is returned when waiting stops because the context was cancelled, usually because the user
+// interrupted Terraform. It is returned as is, instead of wrapped in the generic waiting error, so that the message is
+// clear.
+var ErrCancelled = errors.New("operation cancelled by user")
+
+// StateRefreshFunc is a function that returns the current state of a resource.
+// It returns (resource, stateString, error).
+// If the resource is in a failed state, it should return an error.
+// This is an alias for retry.StateRefreshFunc for use in resource and data source implementations.
+type StateRefreshFunc = retry.StateRefreshFunc
+
+// Config contains configuration for waiting for an object to reach a state.
+type Config struct {
+	// PendingStates are the states that indicate the object is still being created/updated
+	PendingStates []string
+	// TargetStates are the states the object is waited for to reach
+	TargetStates []string
+	// RefreshFunc is the function to call to get the current state
+	RefreshFunc retry.StateRefreshFunc
+	// Timeout is the maximum time to wait
+	Timeout time.Duration
+	// PollInterval is the delay before the first poll. The following polls back off from MinPollInterval to 10s,
+	// unless FixedPollInterval is set.
+	PollInterval time.Duration
+	// MinPollInterval is the minimum polling interval
+	MinPollInterval time.Duration
+	// FixedPollInterval, when not zero, replaces the backoff between polls with this constant interval, also used as
+	// the delay before the first poll. Only set it when the user configured an interval explicitly.
+	FixedPollInterval time.Duration
+	// BackoffMultiplier, when greater than one, multiplies the time between polls by this factor after every poll,
+	// starting from FixedPollInterval, or PollInterval when it isn't set, up to MaxPollInterval, with some random
+	// jitter so that concurrent waits don't poll in lockstep. Zero or one keeps the default polling.
+	BackoffMultiplier float64
+	// MaxPollInterval is the longest time between polls when backoff is enabled. Defaults to DefaultMaxPollInterval.
+	MaxPollInterval time.Duration
+	// SlowWarningAfter is the elapsed time after which a warning is logged if the resource is still pending.
+	// Zero disables the warning. Waiting continues until Timeout regardless.
+	SlowWarningAfter time.Duration
+	// Stats, when not nil, is filled with statistics about the wait once it is finished, successfully or not
+	Stats *Stats
+}
+
+// Stats contains statistics about a finished wait, useful to tune timeouts and poll intervals.
+type Stats struct {
+	// Polls is the number of times the state of the object was checked
+	Polls int
+	// Elapsed is the total time spent waiting
+	Elapsed time.Duration
+}
+
+// WaitForReady waits for an object to reach a ready state. The ready states are the target states of the
+// configuration. Returns the final object and any error encountered.
+func WaitForReady(ctx context.Context, config Config) (interface{}, error) {
+	result, err := waitForState(ctx, config)
+	if errors.Is(err, ErrCancelled) {
+		return nil, err
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to reach ready state: %w", err)
+	}
+	return result, nil
+}
+
+// WaitForState waits for an object to reach one of the target states of the configuration. Returns the final
+// object and any error encountered.
+func WaitForState(ctx context.Context, config Config) (interface{}, error) {
+	result, err := waitForState(ctx, config)
+	if errors.Is(err, ErrCancelled) {
+		return nil, err
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to reach state %s: %w", strings.Join(config.TargetStates, " or "), err)
+	}
+	return result, nil
+}
+
+// waitForState polls the object using the AWS-style StateChangeConf pattern.
+func waitForState(ctx context.Context, config Config) (interface{}, error) {
+	// Apply defaults
+	if config.Timeout == 0 {
+		config.Timeout = DefaultCreateTimeout
+	}
+	if config.PollInterval == 0 {
+		config.PollInterval = DefaultPollInterval
+	}
+	if config.MinPollInterval == 0 {
+		config.MinPollInterval = DefaultMinPollInterval
+	}
+	if config.MaxPollInterval == 0 {
+		config.MaxPollInterval = DefaultMaxPollInterval
+	}
+
+	// Count the polls. The refresh function runs in a separate goroutine, that may still be running when the wait
+	// times out, hence the atomic counter.
+	var polls atomic.Int64
+	refresh := func() (interface{}, string, error) {
+		polls.Add(1)
+		return config.RefreshFunc()
+	}
+	delay := config.PollInterval
+	interval := config.FixedPollInterval
+	if interval > 0 {
+		delay = interval
+	}
+	if config.BackoffMultiplier > 1 {
+		// The state change loop polls at the base interval, and the wrapper adds the rest of the backoff
+		if interval == 0 {
+			interval = config.PollInterval
+		}
+		refresh = backoffRefreshFunc(ctx, refresh, interval, config.MaxPollInterval, config.BackoffMultiplier)
+	}
+	if config.SlowWarningAfter > 0 {
+		refresh = slowWarningRefreshFunc(ctx, refresh, config.TargetStates, config.SlowWarningAfter, config.Timeout)
+	}
+
+	stateConf := &retry.StateChangeConf{
+		Pending:      config.PendingStates,
+		Target:       config.TargetStates,
+		Refresh:      refresh,
+		Timeout:      config.Timeout,
+		Delay:        delay,
+		PollInterval: interval,
+		MinTimeout:   config.MinPollInterval,
+	}
+
+	start := time.Now()
+	result, err := stateConf.WaitForStateContext(ctx)
+	stats := Stats{
+		Polls:   int(polls.Load()),
+		Elapsed: time.Since(start),
+	}
+	tflog.Debug(ctx, "Finished waiting", map[string]interface{}{
+		"targets": config.TargetStates,
+		"polls":   stats.Polls,
+		"elapsed": stats.Elapsed.Round(time.Second).String(),
+		"success": err == nil,
+	})
+	if config.Stats != nil {
+		*config.Stats = stats
+	}
+
+	// The state change loop returns as soon as the context is done, but a refresh that was in progress may also
+	// have failed with a cancellation error of its own. Either way report the cancellation, not the side effects.
+	if err != nil && errors.Is(ctx.Err(), context.Canceled) {
+		return nil, ErrCancelled
+	}
+	return result, err
+}
+
+// LogTransitions wraps the refresh function of an object so that every change of its state is logged at the info
+// level, like "cluster 123: PROGRESSING -> READY", and every other poll at the debug level. The logs include the kind
+// and identifier of the object and the time elapsed since the wrapper was created, so running with TF_LOG=INFO shows
+// the progress of long waits.
+func LogTransitions(ctx context.Context, kind, id string, refresh StateRefreshFunc) StateRefreshFunc {
+	start := time.Now()
+	previous := ""
+	return func() (interface{}, string, error) {
+		result, state, err := refresh()
+		fields := map[string]interface{}{
+			"kind":    kind,
+			"id":      id,
+			"state":   state,
+			"elapsed": time.Since(start).Round(time.Second).String(),
+		}
+		switch {
+		case state == "":
+			// The state couldn't be read, the error is reported by the wait itself
+		case previous == "":
+			tflog.Info(ctx, fmt.Sprintf("%s %s: %s", kind, id, state), fields)
+		case state != previous:
+			fields["previous_state"] = previous
+			tflog.Info(ctx, fmt.Sprintf("%s %s: %s -> %s", kind, id, previous, state), fields)
+		default:
+			tflog.Debug(ctx, fmt.Sprintf("%s %s: still %s", kind, id, state), fields)
+		}
+		if state != "" {
+			previous = state
+		}
+		return result, state, err
+	}
+}
+
+// backoffRefreshFunc wraps a refresh function so that the time between polls grows exponentially, up to the given
+// limit. The state change loop already waits the base interval between polls, so the wrapper only sleeps the extra
+// time, and stops sleeping as soon as the context is cancelled. Up to a quarter of every interval is randomly cut, but
+// never below the base interval, so that many objects created at the same time spread their polls.
+func backoffRefreshFunc(ctx context.Context, refresh retry.StateRefreshFunc, base, limit time.Duration, multiplier float64) retry.StateRefreshFunc {
+	var interval time.Duration
+	return func() (interface{}, string, error) {
+		if interval == 0 {
+			// The first poll isn't delayed.
+			interval = base
+			return refresh()
+		}
+		interval = min(time.Duration(float64(interval)*multiplier), limit)
+		wait := interval
+		if wait >= 4 {
+			wait -= rand.N(wait / 4)
+		}
+		if extra := wait - base; extra > 0 {
+			timer := time.NewTimer(extra)
+			select {
+			case <-timer.C:
+			case <-ctx.Done():
+				timer.Stop()
+				return nil, "", ctx.Err()
+			}
+		}
+		return refresh()
+	}
+}
+
+// slowWarningRefreshFunc wraps a refresh function so that a single warning is logged, including the current state,
+// the first time the resource is still not in a target state after the given threshold.
+func slowWarningRefreshFunc(ctx context.Context, refresh retry.StateRefreshFunc, targets []string, threshold, timeout time.Duration) retry.StateRefreshFunc {
+	start := time.Now()
+	warned := false
+	return func() (interface{}, string, error) {
+		result, state, err := refresh()
+		elapsed := time.Since(start)
+		if err == nil && !warned && elapsed >= threshold && !slices.Contains(targets, state) {
+			warned = true
+			tflog.Warn(ctx, "Operation is taking longer than expected, still waiting", map[string]interface{}{
+				"state":   state,
+				"elapsed": elapsed.Round(time.Second).String(),
+				"timeout": timeout.String(),
+			})
+		}
+		return result, state, err
+	}
+}